@@ -72,7 +72,7 @@ func NewCommand() *cobra.Command {
 				return err
 			}
 			ctx, cancel := context.WithCancel(cmd.Context())
-			return run(ctx, cancel, log, opts.config)
+			return run(ctx, cancel, log, opts.config, NewFS(opts.fsRoot))
 		},
 	}
 
@@ -94,7 +94,7 @@ func getBootstrapCommand(opts *options) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return bootstrap.Bootstrap(cmd.Context(), log, afero.Afero{Fs: afero.NewOsFs()}, dbus.New(), opts.config.Bootstrap)
+			return bootstrap.Bootstrap(cmd.Context(), log, NewFS(opts.fsRoot), dbus.New(), opts.config.Bootstrap)
 		},
 	}
 
@@ -105,7 +105,16 @@ func getBootstrapCommand(opts *options) *cobra.Command {
 	return bootstrapCmd
 }
 
-func run(ctx context.Context, cancel context.CancelFunc, log logr.Logger, cfg *config.NodeAgentConfiguration) error {
+// NewFS returns the filesystem abstraction used for all file operations of the gardener-node-agent. When fsRoot is
+// set, all paths are prefixed with it so that the writes of the agent are confined to this directory.
+func NewFS(fsRoot string) afero.Afero {
+	if fsRoot == "" {
+		return afero.Afero{Fs: afero.NewOsFs()}
+	}
+	return afero.Afero{Fs: afero.NewBasePathFs(afero.NewOsFs(), fsRoot)}
+}
+
+func run(ctx context.Context, cancel context.CancelFunc, log logr.Logger, cfg *config.NodeAgentConfiguration, fs afero.Afero) error {
 	log.Info("Feature Gates", "featureGates", features.DefaultFeatureGate)
 
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
@@ -192,7 +201,7 @@ func run(ctx context.Context, cancel context.CancelFunc, log logr.Logger, cfg *c
 	}
 
 	log.Info("Adding controllers to manager")
-	if err := controller.AddToManager(cancel, mgr, cfg, nodeName); err != nil {
+	if err := controller.AddToManager(cancel, mgr, cfg, nodeName, fs); err != nil {
 		return fmt.Errorf("failed adding controllers to manager: %w", err)
 	}
 
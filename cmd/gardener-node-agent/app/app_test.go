@@ -0,0 +1,50 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/gardener/gardener/cmd/gardener-node-agent/app"
+)
+
+var _ = Describe("App", func() {
+	Describe("#NewFS", func() {
+		It("should prefix all paths with the given root directory", func() {
+			rootDir := GinkgoT().TempDir()
+			fs := NewFS(rootDir)
+
+			Expect(fs.MkdirAll("/etc/foo", 0755)).To(Succeed())
+			Expect(fs.WriteFile("/etc/foo/bar.txt", []byte("hello"), 0600)).To(Succeed())
+
+			content, err := os.ReadFile(filepath.Join(rootDir, "etc", "foo", "bar.txt"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("hello"))
+		})
+
+		It("should use the regular filesystem when no root directory is given", func() {
+			rootDir := GinkgoT().TempDir()
+			fs := NewFS("")
+
+			exists, err := fs.DirExists(rootDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+	})
+})
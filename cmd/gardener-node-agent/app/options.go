@@ -44,6 +44,7 @@ func init() {
 
 type options struct {
 	configFile string
+	fsRoot     string
 	config     *config.NodeAgentConfiguration
 }
 
@@ -51,6 +52,7 @@ var _ utils.Options = &options{}
 
 func (o *options) addFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.configFile, "config", o.configFile, "Path to configuration file.")
+	fs.StringVar(&o.fsRoot, "fs-root", o.fsRoot, "Optional base directory all filesystem writes are confined to. All file and unit paths are prefixed with this directory so that the agent can run hermetically, e.g. in a container during tests.")
 }
 
 func (o *options) Complete() error {
@@ -86,6 +86,60 @@ mkdir -p "` + unitDropInsDirectoryPath + `"`
 	return out, nil
 }
 
+const (
+	// estimatedScriptOverheadPerFile is the estimated size in bytes of the mkdir/cat/chmod boilerplate which
+	// FilesToDiskScript renders per file (excluding the file path and content).
+	estimatedScriptOverheadPerFile = 64
+	// estimatedScriptOverheadPerUnit is the estimated size in bytes of the cat boilerplate which UnitsToDiskScript
+	// renders per unit resp. drop-in (including the unit file path below /etc/systemd/system).
+	estimatedScriptOverheadPerUnit = 80
+)
+
+// EstimateScriptSize estimates the length in bytes of the scripts rendered by FilesToDiskScript and UnitsToDiskScript
+// for the given files and units without actually rendering them. Contents referenced from secrets are not part of the
+// estimate since resolving them requires API calls. The result is only an approximation, callers must leave some
+// head room when comparing it against a size budget.
+func EstimateScriptSize(files []extensionsv1alpha1.File, units []extensionsv1alpha1.Unit) int {
+	size := 0
+
+	for _, file := range files {
+		size += estimateFileScriptSize(file)
+	}
+
+	for _, unit := range units {
+		if unit.Content != nil {
+			size += estimatedScriptOverheadPerUnit + len(unit.Name) + len(*unit.Content)*4/3
+		}
+
+		for _, dropIn := range unit.DropIns {
+			size += estimatedScriptOverheadPerUnit + len(unit.Name) + len(dropIn.Name) + len(dropIn.Content)*4/3
+		}
+
+		for _, file := range unit.Files {
+			size += estimateFileScriptSize(file)
+		}
+	}
+
+	return size
+}
+
+func estimateFileScriptSize(file extensionsv1alpha1.File) int {
+	// the file path appears in the mkdir, cat, and chmod lines
+	size := estimatedScriptOverheadPerFile + 3*len(file.Path)
+
+	if inline := file.Content.Inline; inline != nil {
+		if inline.Encoding == "b64" || pointer.BoolDeref(file.Content.TransmitUnencoded, false) {
+			// the data is already base64-encoded resp. written unencoded, so the rendered size matches the data size
+			size += len(inline.Data)
+		} else {
+			// plain data is base64-encoded when rendered
+			size += len(inline.Data) * 4 / 3
+		}
+	}
+
+	return size
+}
+
 func dataForFileContent(ctx context.Context, c client.Reader, namespace string, content *extensionsv1alpha1.FileContent) ([]byte, error) {
 	if inline := content.Inline; inline != nil {
 		return extensionsv1alpha1helper.Decode(inline.Encoding, []byte(inline.Data))
@@ -245,6 +245,63 @@ EOF`))
 			)
 		})
 	})
+
+	Describe("#EstimateScriptSize", func() {
+		It("should estimate the rendered script length within a tolerance", func() {
+			files := []extensionsv1alpha1.File{
+				{
+					Path:        file1,
+					Permissions: pointer.Int32(0644),
+					Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{
+						Data: strings.Repeat("plain-data", 100),
+					}},
+				},
+				{
+					Path: "/bar/baz",
+					Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{
+						Encoding: "b64",
+						Data:     "YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXo=",
+					}},
+				},
+				{
+					Path: "/baz/foo",
+					Content: extensionsv1alpha1.FileContent{
+						Inline:            &extensionsv1alpha1.FileContentInline{Data: "transmit-unencoded"},
+						TransmitUnencoded: pointer.Bool(true),
+					},
+				},
+			}
+
+			units := []extensionsv1alpha1.Unit{{
+				Name:    "some.service",
+				Content: pointer.String(strings.Repeat("unit-content\n", 20)),
+				DropIns: []extensionsv1alpha1.DropIn{{Name: "drop-in", Content: "drop-in-content"}},
+				Files: []extensionsv1alpha1.File{{
+					Path:    "/foobar/baz",
+					Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "unit-file-content"}},
+				}},
+			}}
+
+			filesScript, err := FilesToDiskScript(ctx, fakeClient, namespace, files)
+			Expect(err).NotTo(HaveOccurred())
+			unitsScript, err := UnitsToDiskScript(ctx, fakeClient, namespace, units)
+			Expect(err).NotTo(HaveOccurred())
+
+			actualSize := len(filesScript + unitsScript)
+			Expect(EstimateScriptSize(files, units)).To(BeNumerically("~", actualSize, actualSize/5))
+		})
+
+		It("should not account for contents referenced from secrets", func() {
+			files := []extensionsv1alpha1.File{{
+				Path: file1,
+				Content: extensionsv1alpha1.FileContent{
+					SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "foo", DataKey: "bar"},
+				},
+			}}
+
+			Expect(EstimateScriptSize(files, nil)).To(BeNumerically("<", 128))
+		})
+	})
 })
 
 func runScriptAndCheckFiles(script string, filePaths ...string) {
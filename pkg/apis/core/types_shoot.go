@@ -171,6 +171,34 @@ type LastMaintenance struct {
 	State LastOperationState
 	// FailureReason holds the information about the last maintenance operation failure reason.
 	FailureReason *string
+	// Changes optionally holds structured per-target timing information about the changes processed in the last
+	// maintenance.
+	Changes []MaintenanceChange
+	// CloudProfileResourceVersion is the resourceVersion of the CloudProfile the maintenance decisions were based
+	// on. It allows correlating a maintenance operation with the exact profile state observed by the controller.
+	CloudProfileResourceVersion *string
+	// AvailableUpdates optionally lists, per target, version updates which are available under the configured update
+	// strategy but were not applied because auto-update is disabled for the target.
+	AvailableUpdates []AvailableUpdate
+}
+
+// MaintenanceChange holds timing information about the processing of a single target during a maintenance operation.
+type MaintenanceChange struct {
+	// Target is the entity that was processed, e.g. the control plane or the name of a worker pool.
+	Target string
+	// StartTime is the time when processing of the target started.
+	StartTime *metav1.Time
+	// EndTime is the time when processing of the target finished.
+	EndTime *metav1.Time
+}
+
+// AvailableUpdate describes a version update which is available for a target but was not applied during the last
+// maintenance, e.g. because auto-update is disabled for it.
+type AvailableUpdate struct {
+	// Target is the entity the update is available for, e.g. the control plane or the name of a worker pool.
+	Target string
+	// Version is the latest version eligible under the configured update strategy which is newer than the current one.
+	Version string
 }
 
 // ShootCredentials contains information about the shoot credentials.
@@ -1003,6 +1031,11 @@ type Maintenance struct {
 	// Instead, they are rolled out during the shoot's maintenance time window. There is one exception that will trigger
 	// an immediate roll out which is changes to the Spec.Hibernation.Enabled field.
 	ConfineSpecUpdateRollout *bool
+	// Schedule is an optional standard cron expression (see https://en.wikipedia.org/wiki/Cron) that gates on which
+	// days the configured maintenance time window is effective. When set, maintenance operations are only performed
+	// when the cron expression matches a point in time on the current day, e.g. `0 0 * * 0` restricts maintenance to
+	// Sundays.
+	Schedule *string
 }
 
 // MaintenanceAutoUpdate contains information about which constraints should be automatically updated.
@@ -1100,6 +1133,16 @@ type Worker struct {
 	MachineControllerManagerSettings *MachineControllerManagerSettings
 	// Sysctls is a map of kernel settings to apply on all machines in this worker pool.
 	Sysctls map[string]string
+	// Maintenance contains maintenance settings for this worker pool.
+	Maintenance *WorkerMaintenance
+}
+
+// WorkerMaintenance contains maintenance settings for a worker pool.
+type WorkerMaintenance struct {
+	// Canary marks this worker pool as canary pool for maintenance. When a version update applies to multiple worker
+	// pools, canary pools are updated first and the update of the remaining pools is deferred to a subsequent
+	// maintenance window.
+	Canary bool
 }
 
 // MachineControllerManagerSettings contains configurations for different worker-pools. Eg. MachineDrainTimeout, MachineHealthTimeout.
@@ -1155,6 +1198,12 @@ type ShootMachineImage struct {
 	// Version is the version of the shoot's image.
 	// If version is not provided, it will be defaulted to the latest version from the CloudProfile.
 	Version string
+	// UpdateStrategy is the update strategy to use for this machine image during maintenance. If set, it overrides the
+	// update strategy configured for the machine image in the CloudProfile. Possible values are `patch`, `minor` and `major`.
+	UpdateStrategy *MachineImageUpdateStrategy
+	// MaximumVersion is the highest version automatic updates during maintenance may update this machine image to.
+	// Force-updates of expired versions are still allowed to exceed this version.
+	MaximumVersion *string
 }
 
 // Volume contains information about the volume type and size.
@@ -358,6 +358,15 @@ const (
 	// ShootOperationMaintain is a constant for an annotation on a Shoot indicating that the Shoot maintenance shall be
 	// executed as soon as possible.
 	ShootOperationMaintain = "maintain"
+	// ShootOperationMaintainNow is a constant for the value of the maintenance operation annotation enforcing an
+	// immediate maintenance run even outside the Shoot's maintenance time window.
+	ShootOperationMaintainNow = "maintain-now"
+	// ShootOperationMaintainControlPlane is a constant for the value of the maintenance operation annotation
+	// restricting the next maintenance run to the control plane, i.e. the worker pools are not maintained in that run.
+	ShootOperationMaintainControlPlane = "maintain-controlplane"
+	// ShootOperationMaintainWorkers is a constant for the value of the maintenance operation annotation restricting the
+	// next maintenance run to the worker pools, i.e. the control plane is not maintained in that run.
+	ShootOperationMaintainWorkers = "maintain-workers"
 	// ShootOperationRetry is a constant for an annotation on a Shoot indicating that a failed Shoot reconciliation shall be
 	// retried.
 	ShootOperationRetry = "retry"
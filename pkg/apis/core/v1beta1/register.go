@@ -63,6 +63,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ExposureClassList{},
 		&InternalSecret{},
 		&InternalSecretList{},
+		&MaintenancePolicy{},
+		&MaintenancePolicyList{},
 		&Project{},
 		&ProjectList{},
 		&Quota{},
@@ -216,6 +216,39 @@ type LastMaintenance struct {
 	// FailureReason holds the information about the last maintenance operation failure reason.
 	// +optional
 	FailureReason *string `json:"failureReason,omitempty" protobuf:"bytes,4,opt,name=failureReason"`
+	// Changes optionally holds structured per-target timing information about the changes processed in the last
+	// maintenance.
+	// +optional
+	Changes []MaintenanceChange `json:"changes,omitempty" protobuf:"bytes,5,rep,name=changes"`
+	// CloudProfileResourceVersion is the resourceVersion of the CloudProfile the maintenance decisions were based
+	// on. It allows correlating a maintenance operation with the exact profile state observed by the controller.
+	// +optional
+	CloudProfileResourceVersion *string `json:"cloudProfileResourceVersion,omitempty" protobuf:"bytes,6,opt,name=cloudProfileResourceVersion"`
+	// AvailableUpdates optionally lists, per target, version updates which are available under the configured update
+	// strategy but were not applied because auto-update is disabled for the target.
+	// +optional
+	AvailableUpdates []AvailableUpdate `json:"availableUpdates,omitempty" protobuf:"bytes,7,rep,name=availableUpdates"`
+}
+
+// MaintenanceChange holds timing information about the processing of a single target during a maintenance operation.
+type MaintenanceChange struct {
+	// Target is the entity that was processed, e.g. the control plane or the name of a worker pool.
+	Target string `json:"target" protobuf:"bytes,1,opt,name=target"`
+	// StartTime is the time when processing of the target started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty" protobuf:"bytes,2,opt,name=startTime"`
+	// EndTime is the time when processing of the target finished.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty" protobuf:"bytes,3,opt,name=endTime"`
+}
+
+// AvailableUpdate describes a version update which is available for a target but was not applied during the last
+// maintenance, e.g. because auto-update is disabled for it.
+type AvailableUpdate struct {
+	// Target is the entity the update is available for, e.g. the control plane or the name of a worker pool.
+	Target string `json:"target" protobuf:"bytes,1,opt,name=target"`
+	// Version is the latest version eligible under the configured update strategy which is newer than the current one.
+	Version string `json:"version" protobuf:"bytes,2,opt,name=version"`
 }
 
 // ShootCredentials contains information about the shoot credentials.
@@ -1288,6 +1321,12 @@ type Maintenance struct {
 	// an immediate roll out which is changes to the Spec.Hibernation.Enabled field.
 	// +optional
 	ConfineSpecUpdateRollout *bool `json:"confineSpecUpdateRollout,omitempty" protobuf:"varint,3,opt,name=confineSpecUpdateRollout"`
+	// Schedule is an optional standard cron expression (see https://en.wikipedia.org/wiki/Cron) that gates on which
+	// days the configured maintenance time window is effective. When set, maintenance operations are only performed
+	// when the cron expression matches a point in time on the current day, e.g. `0 0 * * 0` restricts maintenance to
+	// Sundays.
+	// +optional
+	Schedule *string `json:"schedule,omitempty" protobuf:"bytes,4,opt,name=schedule"`
 }
 
 // MaintenanceAutoUpdate contains information about which constraints should be automatically updated.
@@ -1414,6 +1453,18 @@ type Worker struct {
 	// Sysctls is a map of kernel settings to apply on all machines in this worker pool.
 	// +optional
 	Sysctls map[string]string `json:"sysctls,omitempty" protobuf:"bytes,20,rep,name=sysctls"`
+	// Maintenance contains maintenance settings for this worker pool.
+	// +optional
+	Maintenance *WorkerMaintenance `json:"maintenance,omitempty" protobuf:"bytes,21,opt,name=maintenance"`
+}
+
+// WorkerMaintenance contains maintenance settings for a worker pool.
+type WorkerMaintenance struct {
+	// Canary marks this worker pool as canary pool for maintenance. When a version update applies to multiple worker
+	// pools, canary pools are updated first and the update of the remaining pools is deferred to a subsequent
+	// maintenance window.
+	// +optional
+	Canary bool `json:"canary,omitempty" protobuf:"varint,1,opt,name=canary"`
 }
 
 // MachineControllerManagerSettings contains configurations for different worker-pools. Eg. MachineDrainTimeout, MachineHealthTimeout.
@@ -1480,6 +1531,14 @@ type ShootMachineImage struct {
 	// If version is not provided, it will be defaulted to the latest version from the CloudProfile.
 	// +optional
 	Version *string `json:"version,omitempty" protobuf:"bytes,3,opt,name=version"`
+	// UpdateStrategy is the update strategy to use for this machine image during maintenance. If set, it overrides the
+	// update strategy configured for the machine image in the CloudProfile. Possible values are `patch`, `minor` and `major`.
+	// +optional
+	UpdateStrategy *MachineImageUpdateStrategy `json:"updateStrategy,omitempty" protobuf:"bytes,4,opt,name=updateStrategy,casttype=MachineImageUpdateStrategy"`
+	// MaximumVersion is the highest version automatic updates during maintenance may update this machine image to.
+	// Force-updates of expired versions are still allowed to exceed this version.
+	// +optional
+	MaximumVersion *string `json:"maximumVersion,omitempty" protobuf:"bytes,5,opt,name=maximumVersion"`
 }
 
 // Volume contains information about the volume type, size, and encryption.
@@ -1632,6 +1691,8 @@ const (
 	ShootEventImageVersionMaintenance = "MachineImageVersionMaintenance"
 	// ShootEventK8sVersionMaintenance indicates that a maintenance operation regarding the K8s version has been performed.
 	ShootEventK8sVersionMaintenance = "KubernetesVersionMaintenance"
+	// ShootEventMaintenanceUpdateSkipped indicates that a maintenance operation intentionally skipped an update.
+	ShootEventMaintenanceUpdateSkipped = "MaintenanceUpdateSkipped"
 	// ShootEventHibernationEnabled indicates that hibernation started.
 	ShootEventHibernationEnabled = "Hibernated"
 	// ShootEventHibernationDisabled indicates that hibernation ended.
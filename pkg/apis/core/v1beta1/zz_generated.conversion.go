@@ -917,6 +917,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceChange)(nil), (*core.MaintenanceChange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_MaintenanceChange_To_core_MaintenanceChange(a.(*MaintenanceChange), b.(*core.MaintenanceChange), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*core.MaintenanceChange)(nil), (*MaintenanceChange)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_core_MaintenanceChange_To_v1beta1_MaintenanceChange(a.(*core.MaintenanceChange), b.(*MaintenanceChange), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MaintenanceAutoUpdate)(nil), (*core.MaintenanceAutoUpdate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_MaintenanceAutoUpdate_To_core_MaintenanceAutoUpdate(a.(*MaintenanceAutoUpdate), b.(*core.MaintenanceAutoUpdate), scope)
 	}); err != nil {
@@ -3894,6 +3904,9 @@ func autoConvert_v1beta1_LastMaintenance_To_core_LastMaintenance(in *LastMainten
 	out.TriggeredTime = in.TriggeredTime
 	out.State = core.LastOperationState(in.State)
 	out.FailureReason = (*string)(unsafe.Pointer(in.FailureReason))
+	out.Changes = *(*[]core.MaintenanceChange)(unsafe.Pointer(&in.Changes))
+	out.CloudProfileResourceVersion = (*string)(unsafe.Pointer(in.CloudProfileResourceVersion))
+	out.AvailableUpdates = *(*[]core.AvailableUpdate)(unsafe.Pointer(&in.AvailableUpdates))
 	return nil
 }
 
@@ -3907,6 +3920,9 @@ func autoConvert_core_LastMaintenance_To_v1beta1_LastMaintenance(in *core.LastMa
 	out.TriggeredTime = in.TriggeredTime
 	out.State = LastOperationState(in.State)
 	out.FailureReason = (*string)(unsafe.Pointer(in.FailureReason))
+	out.Changes = *(*[]MaintenanceChange)(unsafe.Pointer(&in.Changes))
+	out.CloudProfileResourceVersion = (*string)(unsafe.Pointer(in.CloudProfileResourceVersion))
+	out.AvailableUpdates = *(*[]AvailableUpdate)(unsafe.Pointer(&in.AvailableUpdates))
 	return nil
 }
 
@@ -4127,6 +4143,7 @@ func autoConvert_v1beta1_Maintenance_To_core_Maintenance(in *Maintenance, out *c
 	out.AutoUpdate = (*core.MaintenanceAutoUpdate)(unsafe.Pointer(in.AutoUpdate))
 	out.TimeWindow = (*core.MaintenanceTimeWindow)(unsafe.Pointer(in.TimeWindow))
 	out.ConfineSpecUpdateRollout = (*bool)(unsafe.Pointer(in.ConfineSpecUpdateRollout))
+	out.Schedule = (*string)(unsafe.Pointer(in.Schedule))
 	return nil
 }
 
@@ -4139,6 +4156,7 @@ func autoConvert_core_Maintenance_To_v1beta1_Maintenance(in *core.Maintenance, o
 	out.AutoUpdate = (*MaintenanceAutoUpdate)(unsafe.Pointer(in.AutoUpdate))
 	out.TimeWindow = (*MaintenanceTimeWindow)(unsafe.Pointer(in.TimeWindow))
 	out.ConfineSpecUpdateRollout = (*bool)(unsafe.Pointer(in.ConfineSpecUpdateRollout))
+	out.Schedule = (*string)(unsafe.Pointer(in.Schedule))
 	return nil
 }
 
@@ -4147,6 +4165,30 @@ func Convert_core_Maintenance_To_v1beta1_Maintenance(in *core.Maintenance, out *
 	return autoConvert_core_Maintenance_To_v1beta1_Maintenance(in, out, s)
 }
 
+func autoConvert_v1beta1_MaintenanceChange_To_core_MaintenanceChange(in *MaintenanceChange, out *core.MaintenanceChange, s conversion.Scope) error {
+	out.Target = in.Target
+	out.StartTime = (*metav1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*metav1.Time)(unsafe.Pointer(in.EndTime))
+	return nil
+}
+
+// Convert_v1beta1_MaintenanceChange_To_core_MaintenanceChange is an autogenerated conversion function.
+func Convert_v1beta1_MaintenanceChange_To_core_MaintenanceChange(in *MaintenanceChange, out *core.MaintenanceChange, s conversion.Scope) error {
+	return autoConvert_v1beta1_MaintenanceChange_To_core_MaintenanceChange(in, out, s)
+}
+
+func autoConvert_core_MaintenanceChange_To_v1beta1_MaintenanceChange(in *core.MaintenanceChange, out *MaintenanceChange, s conversion.Scope) error {
+	out.Target = in.Target
+	out.StartTime = (*metav1.Time)(unsafe.Pointer(in.StartTime))
+	out.EndTime = (*metav1.Time)(unsafe.Pointer(in.EndTime))
+	return nil
+}
+
+// Convert_core_MaintenanceChange_To_v1beta1_MaintenanceChange is an autogenerated conversion function.
+func Convert_core_MaintenanceChange_To_v1beta1_MaintenanceChange(in *core.MaintenanceChange, out *MaintenanceChange, s conversion.Scope) error {
+	return autoConvert_core_MaintenanceChange_To_v1beta1_MaintenanceChange(in, out, s)
+}
+
 func autoConvert_v1beta1_MaintenanceAutoUpdate_To_core_MaintenanceAutoUpdate(in *MaintenanceAutoUpdate, out *core.MaintenanceAutoUpdate, s conversion.Scope) error {
 	out.KubernetesVersion = in.KubernetesVersion
 	out.MachineImageVersion = (*bool)(unsafe.Pointer(in.MachineImageVersion))
@@ -5792,6 +5834,8 @@ func autoConvert_v1beta1_ShootMachineImage_To_core_ShootMachineImage(in *ShootMa
 	if err := metav1.Convert_Pointer_string_To_string(&in.Version, &out.Version, s); err != nil {
 		return err
 	}
+	out.UpdateStrategy = (*core.MachineImageUpdateStrategy)(unsafe.Pointer(in.UpdateStrategy))
+	out.MaximumVersion = (*string)(unsafe.Pointer(in.MaximumVersion))
 	return nil
 }
 
@@ -5806,6 +5850,8 @@ func autoConvert_core_ShootMachineImage_To_v1beta1_ShootMachineImage(in *core.Sh
 	if err := metav1.Convert_string_To_Pointer_string(&in.Version, &out.Version, s); err != nil {
 		return err
 	}
+	out.UpdateStrategy = (*MachineImageUpdateStrategy)(unsafe.Pointer(in.UpdateStrategy))
+	out.MaximumVersion = (*string)(unsafe.Pointer(in.MaximumVersion))
 	return nil
 }
 
@@ -6263,6 +6309,7 @@ func autoConvert_v1beta1_Worker_To_core_Worker(in *Worker, out *core.Worker, s c
 	out.SystemComponents = (*core.WorkerSystemComponents)(unsafe.Pointer(in.SystemComponents))
 	out.MachineControllerManagerSettings = (*core.MachineControllerManagerSettings)(unsafe.Pointer(in.MachineControllerManagerSettings))
 	out.Sysctls = *(*map[string]string)(unsafe.Pointer(&in.Sysctls))
+	out.Maintenance = (*core.WorkerMaintenance)(unsafe.Pointer(in.Maintenance))
 	return nil
 }
 
@@ -6302,6 +6349,7 @@ func autoConvert_core_Worker_To_v1beta1_Worker(in *core.Worker, out *Worker, s c
 	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
 	out.MachineControllerManagerSettings = (*MachineControllerManagerSettings)(unsafe.Pointer(in.MachineControllerManagerSettings))
 	out.Sysctls = *(*map[string]string)(unsafe.Pointer(&in.Sysctls))
+	out.Maintenance = (*WorkerMaintenance)(unsafe.Pointer(in.Maintenance))
 	return nil
 }
 
@@ -70,11 +70,17 @@ var (
 		string(corev1.ServiceExternalTrafficPolicyTypeLocal),
 	)
 	availableShootOperations = sets.New(
+		v1beta1constants.GardenerOperationReconcile,
 		v1beta1constants.ShootOperationMaintain,
 		v1beta1constants.ShootOperationRetry,
-	).Union(availableShootMaintenanceOperations)
+	).Union(availableCredentialsRotationOperations)
 	availableShootMaintenanceOperations = sets.New(
 		v1beta1constants.GardenerOperationReconcile,
+		v1beta1constants.ShootOperationMaintainNow,
+		v1beta1constants.ShootOperationMaintainControlPlane,
+		v1beta1constants.ShootOperationMaintainWorkers,
+	).Union(availableCredentialsRotationOperations)
+	availableCredentialsRotationOperations = sets.New(
 		v1beta1constants.OperationRotateCAStart,
 		v1beta1constants.OperationRotateCAComplete,
 		v1beta1constants.ShootOperationRotateKubeconfigCredentials,
@@ -1413,6 +1419,12 @@ func validateMaintenance(maintenance *core.Maintenance, fldPath *field.Path, wor
 		}
 	}
 
+	if maintenance.Schedule != nil {
+		if _, err := cron.ParseStandard(*maintenance.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), *maintenance.Schedule, fmt.Sprintf("not a valid cron spec: %v", err)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -1494,6 +1506,9 @@ func ValidateWorker(worker core.Worker, kubernetes core.Kubernetes, fldPath *fie
 		if !inTemplate && len(worker.Machine.Image.Version) == 0 {
 			allErrs = append(allErrs, field.Required(fldPath.Child("machine", "image", "version"), "must specify a machine image version"))
 		}
+		if worker.Machine.Image.UpdateStrategy != nil && !availableUpdateStrategiesForMachineImage.Has(string(*worker.Machine.Image.UpdateStrategy)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("machine", "image", "updateStrategy"), *worker.Machine.Image.UpdateStrategy, sets.List(availableUpdateStrategiesForMachineImage)))
+		}
 	}
 	if worker.Minimum < 0 {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("minimum"), worker.Minimum, "minimum value must not be negative"))
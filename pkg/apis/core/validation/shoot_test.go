@@ -4527,6 +4527,13 @@ var _ = Describe("Shoot Validation Tests", func() {
 				Expect(ValidateShoot(shoot)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
 					"Type":  Equal(field.ErrorTypeNotSupported),
 					"Field": Equal("metadata.annotations[maintenance.gardener.cloud/operation]"),
+					"Detail": And(
+						ContainSubstring("supported values"),
+						ContainSubstring("maintain-now"),
+						ContainSubstring("rotate-ca-start"),
+						ContainSubstring("rotate-observability-credentials"),
+						ContainSubstring("rotate-credentials-start"),
+					),
 				}))))
 			})
 
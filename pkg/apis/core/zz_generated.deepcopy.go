@@ -243,6 +243,22 @@ func (in *AvailabilityZone) DeepCopy() *AvailabilityZone {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AvailableUpdate) DeepCopyInto(out *AvailableUpdate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailableUpdate.
+func (in *AvailableUpdate) DeepCopy() *AvailableUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(AvailableUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupBucket) DeepCopyInto(out *BackupBucket) {
 	*out = *in
@@ -2529,6 +2545,23 @@ func (in *LastMaintenance) DeepCopyInto(out *LastMaintenance) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]MaintenanceChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CloudProfileResourceVersion != nil {
+		in, out := &in.CloudProfileResourceVersion, &out.CloudProfileResourceVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.AvailableUpdates != nil {
+		in, out := &in.AvailableUpdates, &out.AvailableUpdates
+		*out = make([]AvailableUpdate, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2766,6 +2799,11 @@ func (in *Maintenance) DeepCopyInto(out *Maintenance) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -2800,6 +2838,30 @@ func (in *MaintenanceAutoUpdate) DeepCopy() *MaintenanceAutoUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceChange) DeepCopyInto(out *MaintenanceChange) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceChange.
+func (in *MaintenanceChange) DeepCopy() *MaintenanceChange {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MaintenanceTimeWindow) DeepCopyInto(out *MaintenanceTimeWindow) {
 	*out = *in
@@ -4567,6 +4629,16 @@ func (in *ShootMachineImage) DeepCopyInto(out *ShootMachineImage) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(MachineImageUpdateStrategy)
+		**out = **in
+	}
+	if in.MaximumVersion != nil {
+		in, out := &in.MaximumVersion, &out.MaximumVersion
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -5209,6 +5281,11 @@ func (in *Worker) DeepCopyInto(out *Worker) {
 			(*out)[key] = val
 		}
 	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(WorkerMaintenance)
+		**out = **in
+	}
 	return
 }
 
@@ -5248,6 +5325,22 @@ func (in *WorkerKubernetes) DeepCopy() *WorkerKubernetes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerMaintenance) DeepCopyInto(out *WorkerMaintenance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerMaintenance.
+func (in *WorkerMaintenance) DeepCopy() *WorkerMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerSystemComponents) DeepCopyInto(out *WorkerSystemComponents) {
 	*out = *in
@@ -15,15 +15,20 @@
 package helper
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/base64"
 	"fmt"
+	"io"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
 
 var validFileCodecIDs = map[extensionsv1alpha1.FileCodecID]struct{}{
-	extensionsv1alpha1.PlainFileCodecID: {},
-	extensionsv1alpha1.B64FileCodecID:   {},
+	extensionsv1alpha1.PlainFileCodecID:   {},
+	extensionsv1alpha1.B64FileCodecID:     {},
+	extensionsv1alpha1.GzipFileCodecID:    {},
+	extensionsv1alpha1.GzipB64FileCodecID: {},
 }
 
 // FileCodec is a codec to en- and decode data in cloud-init scripts with.j
@@ -37,6 +42,10 @@ var (
 	PlainFileCodec FileCodec = plainFileCodec{}
 	// B64FileCodec is the base64 FileCodec.
 	B64FileCodec FileCodec = b64FileCodec{}
+	// GzipFileCodec is the gzip FileCodec.
+	GzipFileCodec FileCodec = gzipFileCodec{}
+	// GzipB64FileCodec is the FileCodec for base64-encoded gzip data.
+	GzipB64FileCodec FileCodec = gzipB64FileCodec{}
 )
 
 type plainFileCodec struct{}
@@ -64,6 +73,55 @@ func (b64FileCodec) Decode(data []byte) ([]byte, error) {
 	return dst[:n], err
 }
 
+type gzipFileCodec struct{}
+
+func (gzipFileCodec) Encode(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gzipFileCodec) Decode(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("data is no valid gzip stream: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress gzip data: %w", err)
+	}
+
+	return decoded, nil
+}
+
+type gzipB64FileCodec struct{}
+
+func (gzipB64FileCodec) Encode(data []byte) ([]byte, error) {
+	compressed, err := GzipFileCodec.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return B64FileCodec.Encode(compressed)
+}
+
+func (gzipB64FileCodec) Decode(data []byte) ([]byte, error) {
+	compressed, err := B64FileCodec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return GzipFileCodec.Decode(compressed)
+}
+
 // ParseFileCodecID tries to parse a string into a FileCodecID.
 func ParseFileCodecID(s string) (extensionsv1alpha1.FileCodecID, error) {
 	id := extensionsv1alpha1.FileCodecID(s)
@@ -74,8 +132,10 @@ func ParseFileCodecID(s string) (extensionsv1alpha1.FileCodecID, error) {
 }
 
 var fileCodecIDToFileCodec = map[extensionsv1alpha1.FileCodecID]FileCodec{
-	extensionsv1alpha1.PlainFileCodecID: PlainFileCodec,
-	extensionsv1alpha1.B64FileCodecID:   B64FileCodec,
+	extensionsv1alpha1.PlainFileCodecID:   PlainFileCodec,
+	extensionsv1alpha1.B64FileCodecID:     B64FileCodec,
+	extensionsv1alpha1.GzipFileCodecID:    GzipFileCodec,
+	extensionsv1alpha1.GzipB64FileCodecID: GzipB64FileCodec,
 }
 
 // FileCodecForID retrieves the FileCodec for the given FileCodecID.
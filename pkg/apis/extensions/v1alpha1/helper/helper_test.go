@@ -92,5 +92,14 @@ var _ = Describe("filecodec", func() {
 
 		Entry("plain", extensionsv1alpha1.FileContentInline{Encoding: "", Data: "plain data input"}),
 		Entry("base64", extensionsv1alpha1.FileContentInline{Encoding: "b64", Data: "base64 data input"}),
+		Entry("gzip", extensionsv1alpha1.FileContentInline{Encoding: "gzip", Data: "gzip data input"}),
+		Entry("base64-encoded gzip", extensionsv1alpha1.FileContentInline{Encoding: "b64+gzip", Data: "base64 gzip data input"}),
 	)
+
+	Describe("#Decode", func() {
+		It("should fail for data which is no valid gzip stream", func() {
+			_, err := Decode("gzip", []byte("no gzip data"))
+			Expect(err).To(MatchError(ContainSubstring("data is no valid gzip stream")))
+		})
+	})
 })
@@ -101,6 +101,14 @@ type OperatingSystemConfigSpec struct {
 	// +patchStrategy=merge
 	// +optional
 	Files []File `json:"files,omitempty" patchStrategy:"merge" patchMergeKey:"path"`
+	// Sysctls is a map of sysctl settings which are persisted in /etc/sysctl.d and applied on the node by the
+	// gardener-node-agent.
+	// +optional
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+	// KernelModules is a list of kernel modules which are persisted in /etc/modules-load.d and loaded on the node by
+	// the gardener-node-agent.
+	// +optional
+	KernelModules []string `json:"kernelModules,omitempty"`
 }
 
 // Unit is a unit for the operating system configuration (usually, a systemd unit).
@@ -116,6 +124,10 @@ type Unit struct {
 	// Content is the unit's content.
 	// +optional
 	Content *string `json:"content,omitempty"`
+	// ReloadInsteadOfRestart describes whether the unit should be reloaded instead of restarted when it has changed.
+	// If the unit does not support reloading, it is restarted instead.
+	// +optional
+	ReloadInsteadOfRestart *bool `json:"reloadInsteadOfRestart,omitempty"`
 	// DropIns is a list of drop-ins for this unit.
 	// +patchMergeKey=name
 	// +patchStrategy=merge
@@ -165,6 +177,10 @@ type File struct {
 	Permissions *int32 `json:"permissions,omitempty"`
 	// Content describe the file's content.
 	Content FileContent `json:"content"`
+	// SELinuxContext is the SELinux security context label which is applied to the file after it was written. If not
+	// set, the file keeps the default context of the node's policy.
+	// +optional
+	SELinuxContext *string `json:"seLinuxContext,omitempty"`
 }
 
 // FileContent can either reference a secret or contain inline configuration.
@@ -198,6 +214,11 @@ type FileContentInline struct {
 	Encoding string `json:"encoding"`
 	// Data is the file's data.
 	Data string `json:"data"`
+	// Append specifies that the data should be appended to an existing file at the path if it is not already
+	// contained, instead of overwriting the file. When the file is removed from the configuration again, only the
+	// appended data is removed from the file.
+	// +optional
+	Append bool `json:"append,omitempty"`
 }
 
 // FileContentImageRef describes a container image which contains a file
@@ -296,4 +317,8 @@ const (
 	PlainFileCodecID FileCodecID = ""
 	// B64FileCodecID is the base64 file codec id.
 	B64FileCodecID FileCodecID = "b64"
+	// GzipFileCodecID is the gzip file codec id.
+	GzipFileCodecID FileCodecID = "gzip"
+	// GzipB64FileCodecID is the file codec id for base64-encoded gzip data.
+	GzipB64FileCodecID FileCodecID = "b64+gzip"
 )
@@ -1025,6 +1025,11 @@ func (in *File) DeepCopyInto(out *File) {
 		**out = **in
 	}
 	in.Content.DeepCopyInto(&out.Content)
+	if in.SELinuxContext != nil {
+		in, out := &in.SELinuxContext, &out.SELinuxContext
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -1472,6 +1477,18 @@ func (in *OperatingSystemConfigSpec) DeepCopyInto(out *OperatingSystemConfigSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sysctls != nil {
+		in, out := &in.Sysctls, &out.Sysctls
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KernelModules != nil {
+		in, out := &in.KernelModules, &out.KernelModules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1554,6 +1571,11 @@ func (in *Unit) DeepCopyInto(out *Unit) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReloadInsteadOfRestart != nil {
+		in, out := &in.ReloadInsteadOfRestart, &out.ReloadInsteadOfRestart
+		*out = new(bool)
+		**out = **in
+	}
 	if in.DropIns != nil {
 		in, out := &in.DropIns, &out.DropIns
 		*out = make([]DropIn, len(*in))
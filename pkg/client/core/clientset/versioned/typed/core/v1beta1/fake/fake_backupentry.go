@@ -20,12 +20,16 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	errors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
+	names "k8s.io/apiserver/pkg/storage/names"
 	testing "k8s.io/client-go/testing"
 )
 
@@ -39,8 +43,23 @@ var backupentriesResource = v1beta1.SchemeGroupVersion.WithResource("backupentri
 
 var backupentriesKind = v1beta1.SchemeGroupVersion.WithKind("BackupEntry")
 
+// contextDone returns the context's error in case it is already canceled or has exceeded its deadline. The generated
+// fake ignores the context entirely, which makes cancellation paths untestable otherwise.
+func contextDone(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		return nil
+	}
+}
+
 // Get takes name of the backupEntry, and returns the corresponding backupEntry object, and an error if there is any.
 func (c *FakeBackupEntries) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.BackupEntry, err error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
 	obj, err := c.Fake.
 		Invokes(testing.NewGetAction(backupentriesResource, c.ns, name), &v1beta1.BackupEntry{})
 
@@ -52,6 +71,10 @@ func (c *FakeBackupEntries) Get(ctx context.Context, name string, options v1.Get
 
 // List takes label and field selectors, and returns the list of BackupEntries that match those selectors.
 func (c *FakeBackupEntries) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.BackupEntryList, err error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
 	obj, err := c.Fake.
 		Invokes(testing.NewListAction(backupentriesResource, backupentriesKind, c.ns, opts), &v1beta1.BackupEntryList{})
 
@@ -74,6 +97,10 @@ func (c *FakeBackupEntries) List(ctx context.Context, opts v1.ListOptions) (resu
 
 // Watch returns a watch.Interface that watches the requested backupEntries.
 func (c *FakeBackupEntries) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(backupentriesResource, c.ns, opts))
 
@@ -81,6 +108,16 @@ func (c *FakeBackupEntries) Watch(ctx context.Context, opts v1.ListOptions) (wat
 
 // Create takes the representation of a backupEntry and creates it.  Returns the server's representation of the backupEntry, and an error, if there is any.
 func (c *FakeBackupEntries) Create(ctx context.Context, backupEntry *v1beta1.BackupEntry, opts v1.CreateOptions) (result *v1beta1.BackupEntry, err error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
+	if backupEntry != nil && backupEntry.Name == "" && backupEntry.GenerateName != "" {
+		// the real apiserver synthesizes a unique name from metadata.generateName which the fake does not do by itself
+		backupEntry = backupEntry.DeepCopy()
+		backupEntry.Name = names.SimpleNameGenerator.GenerateName(backupEntry.GenerateName)
+	}
+
 	obj, err := c.Fake.
 		Invokes(testing.NewCreateAction(backupentriesResource, c.ns, backupEntry), &v1beta1.BackupEntry{})
 
@@ -92,6 +129,10 @@ func (c *FakeBackupEntries) Create(ctx context.Context, backupEntry *v1beta1.Bac
 
 // Update takes the representation of a backupEntry and updates it. Returns the server's representation of the backupEntry, and an error, if there is any.
 func (c *FakeBackupEntries) Update(ctx context.Context, backupEntry *v1beta1.BackupEntry, opts v1.UpdateOptions) (result *v1beta1.BackupEntry, err error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateAction(backupentriesResource, c.ns, backupEntry), &v1beta1.BackupEntry{})
 
@@ -104,6 +145,10 @@ func (c *FakeBackupEntries) Update(ctx context.Context, backupEntry *v1beta1.Bac
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
 func (c *FakeBackupEntries) UpdateStatus(ctx context.Context, backupEntry *v1beta1.BackupEntry, opts v1.UpdateOptions) (*v1beta1.BackupEntry, error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
 	obj, err := c.Fake.
 		Invokes(testing.NewUpdateSubresourceAction(backupentriesResource, "status", c.ns, backupEntry), &v1beta1.BackupEntry{})
 
@@ -115,6 +160,10 @@ func (c *FakeBackupEntries) UpdateStatus(ctx context.Context, backupEntry *v1bet
 
 // Delete takes name of the backupEntry and deletes it. Returns an error if one occurs.
 func (c *FakeBackupEntries) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	if err := contextDone(ctx); err != nil {
+		return err
+	}
+
 	_, err := c.Fake.
 		Invokes(testing.NewDeleteActionWithOptions(backupentriesResource, c.ns, name, opts), &v1beta1.BackupEntry{})
 
@@ -123,6 +172,10 @@ func (c *FakeBackupEntries) Delete(ctx context.Context, name string, opts v1.Del
 
 // DeleteCollection deletes a collection of objects.
 func (c *FakeBackupEntries) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	if err := contextDone(ctx); err != nil {
+		return err
+	}
+
 	action := testing.NewDeleteCollectionAction(backupentriesResource, c.ns, listOpts)
 
 	_, err := c.Fake.Invokes(action, &v1beta1.BackupEntryList{})
@@ -131,6 +184,10 @@ func (c *FakeBackupEntries) DeleteCollection(ctx context.Context, opts v1.Delete
 
 // Patch applies the patch and returns the patched backupEntry.
 func (c *FakeBackupEntries) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.BackupEntry, err error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
 	obj, err := c.Fake.
 		Invokes(testing.NewPatchSubresourceAction(backupentriesResource, c.ns, name, pt, data, subresources...), &v1beta1.BackupEntry{})
 
@@ -139,3 +196,42 @@ func (c *FakeBackupEntries) Patch(ctx context.Context, name string, pt types.Pat
 	}
 	return obj.(*v1beta1.BackupEntry), err
 }
+
+// Apply takes the given backupEntry and applies it with server-side apply semantics: if the backupEntry does
+// not exist yet, it is created, otherwise the given fields are merged into the tracked object.
+func (c *FakeBackupEntries) Apply(ctx context.Context, backupEntry *v1beta1.BackupEntry, opts v1.ApplyOptions) (result *v1beta1.BackupEntry, err error) {
+	return c.apply(ctx, backupEntry, opts)
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeBackupEntries) ApplyStatus(ctx context.Context, backupEntry *v1beta1.BackupEntry, opts v1.ApplyOptions) (result *v1beta1.BackupEntry, err error) {
+	return c.apply(ctx, backupEntry, opts, "status")
+}
+
+func (c *FakeBackupEntries) apply(ctx context.Context, backupEntry *v1beta1.BackupEntry, opts v1.ApplyOptions, subresources ...string) (result *v1beta1.BackupEntry, err error) {
+	if err := contextDone(ctx); err != nil {
+		return nil, err
+	}
+
+	if backupEntry == nil {
+		return nil, fmt.Errorf("backupEntry provided to Apply must not be nil")
+	}
+	if backupEntry.Name == "" {
+		return nil, fmt.Errorf("backupEntry.Name must be provided to Apply")
+	}
+	data, err := json.Marshal(backupEntry)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(backupentriesResource, c.ns, backupEntry.Name, types.ApplyPatchType, data, subresources...), &v1beta1.BackupEntry{})
+
+	if errors.IsNotFound(err) && len(subresources) == 0 {
+		return c.Create(ctx, backupEntry, v1.CreateOptions{DryRun: opts.DryRun, FieldManager: opts.FieldManager})
+	}
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.BackupEntry), err
+}
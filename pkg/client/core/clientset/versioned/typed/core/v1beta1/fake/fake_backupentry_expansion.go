@@ -0,0 +1,80 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+
+	v1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	scheme "github.com/gardener/gardener/pkg/client/core/clientset/versioned/scheme"
+)
+
+// watchEventChannelSize is the buffer size of the watcher returned by NewSeededBackupEntries. It allows tests
+// to push a couple of events before any consumer started reading from the result channel.
+const watchEventChannelSize = 32
+
+// NewSeededBackupEntries returns a FakeBackupEntries for the given namespace whose object tracker is primed
+// with the given initial objects, together with a watcher for emitting synthetic watch events. Events pushed
+// via the watcher's Add, Modify and Delete methods are delivered to all consumers of the client's Watch
+// method. This replaces the ad-hoc wiring of a testing.Fake with object and watch reactors that tests used to
+// hand-roll for this purpose.
+func NewSeededBackupEntries(namespace string, objects ...*v1beta1.BackupEntry) (*FakeBackupEntries, *watch.FakeWatcher) {
+	tracker := testing.NewObjectTracker(scheme.Scheme, scheme.Codecs.UniversalDecoder())
+	for _, obj := range objects {
+		if err := tracker.Add(obj); err != nil {
+			panic(err)
+		}
+	}
+
+	f := &testing.Fake{}
+	f.AddReactor("*", "*", testing.ObjectReaction(tracker))
+
+	watcher := watch.NewFakeWithChanSize(watchEventChannelSize, false)
+	f.AddWatchReactor("*", testing.DefaultWatchReactor(watcher, nil))
+
+	return &FakeBackupEntries{Fake: &FakeCoreV1beta1{Fake: f}, ns: namespace}, watcher
+}
+
+// RecordedActions returns the actions recorded for backupEntries in this fake's namespace. If <verb> is
+// non-empty, only actions with a matching verb are returned. This is a convenience for test assertions on
+// the action list of the underlying testing.Fake.
+func (c *FakeBackupEntries) RecordedActions(verb string) []testing.Action {
+	var actions []testing.Action
+	for _, action := range c.Fake.Actions() {
+		if action.GetResource() != backupentriesResource || action.GetNamespace() != c.ns {
+			continue
+		}
+		if verb != "" && action.GetVerb() != verb {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// RecordedActionsForName returns the actions recorded for the backupEntry with the given name, optionally
+// filtered by <verb>. Actions which do not carry an object name (e.g. list or watch) are never returned.
+func (c *FakeBackupEntries) RecordedActionsForName(verb, name string) []testing.Action {
+	var actions []testing.Action
+	for _, action := range c.RecordedActions(verb) {
+		namedAction, ok := action.(interface{ GetName() string })
+		if !ok || namedAction.GetName() != name {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
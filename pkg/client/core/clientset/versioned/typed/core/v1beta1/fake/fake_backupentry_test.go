@@ -0,0 +1,201 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	fakeclientset "github.com/gardener/gardener/pkg/client/core/clientset/versioned/fake"
+	"github.com/gardener/gardener/pkg/client/core/clientset/versioned/typed/core/v1beta1/fake"
+)
+
+var _ = Describe("FakeBackupEntries", func() {
+	var (
+		ctx           = context.TODO()
+		backupEntries *fake.FakeBackupEntries
+		backupEntry   *gardencorev1beta1.BackupEntry
+	)
+
+	BeforeEach(func() {
+		clientSet := fakeclientset.NewSimpleClientset()
+		backupEntries = clientSet.CoreV1beta1().BackupEntries("garden").(*fake.FakeBackupEntries)
+
+		backupEntry = &gardencorev1beta1.BackupEntry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "entry",
+				Namespace: "garden",
+			},
+			Spec: gardencorev1beta1.BackupEntrySpec{
+				BucketName: "bucket",
+			},
+		}
+	})
+
+	Describe("#NewSeededBackupEntries", func() {
+		It("should list the initial objects and deliver pushed watch events", func() {
+			seeded, watcher := fake.NewSeededBackupEntries("garden", backupEntry)
+
+			list, err := seeded.List(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Items).To(HaveLen(1))
+			Expect(list.Items[0].Name).To(Equal("entry"))
+
+			w, err := seeded.Watch(ctx, metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+
+			second := backupEntry.DeepCopy()
+			second.Name = "second-entry"
+
+			watcher.Add(second)
+			event := <-w.ResultChan()
+			Expect(event.Type).To(Equal(watch.Added))
+			Expect(event.Object.(*gardencorev1beta1.BackupEntry).Name).To(Equal("second-entry"))
+
+			second.Spec.BucketName = "other-bucket"
+			watcher.Modify(second)
+			event = <-w.ResultChan()
+			Expect(event.Type).To(Equal(watch.Modified))
+			Expect(event.Object.(*gardencorev1beta1.BackupEntry).Spec.BucketName).To(Equal("other-bucket"))
+
+			watcher.Delete(second)
+			event = <-w.ResultChan()
+			Expect(event.Type).To(Equal(watch.Deleted))
+		})
+	})
+
+	Describe("context cancellation", func() {
+		It("should abort calls when the context is already done", func() {
+			canceledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := backupEntries.Get(canceledCtx, "entry", metav1.GetOptions{})
+			Expect(err).To(MatchError(context.Canceled))
+
+			_, err = backupEntries.Create(canceledCtx, backupEntry, metav1.CreateOptions{})
+			Expect(err).To(MatchError(context.Canceled))
+
+			Expect(backupEntries.Delete(canceledCtx, "entry", metav1.DeleteOptions{})).To(MatchError(context.Canceled))
+
+			Expect(backupEntries.RecordedActions("get")).To(BeEmpty(), "no action must reach the tracker")
+			Expect(backupEntries.RecordedActions("create")).To(BeEmpty(), "no action must reach the tracker")
+		})
+	})
+
+	Describe("#Create", func() {
+		It("should synthesize a unique name when only generateName is set", func() {
+			backupEntry.Name = ""
+			backupEntry.GenerateName = "bucket--"
+
+			created, err := backupEntries.Create(ctx, backupEntry, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.Name).To(MatchRegexp(`^bucket--[a-z0-9]{5}$`))
+			Expect(created.GenerateName).To(Equal("bucket--"))
+
+			second, err := backupEntries.Create(ctx, backupEntry.DeepCopy(), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.Name).To(MatchRegexp(`^bucket--[a-z0-9]{5}$`))
+			Expect(second.Name).NotTo(Equal(created.Name))
+		})
+
+		It("should keep an explicitly set name", func() {
+			backupEntry.GenerateName = "bucket--"
+
+			created, err := backupEntries.Create(ctx, backupEntry, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.Name).To(Equal("entry"))
+		})
+	})
+
+	Describe("#Apply", func() {
+		It("should create the backupEntry if it does not exist yet", func() {
+			result, err := backupEntries.Apply(ctx, backupEntry, metav1.ApplyOptions{FieldManager: "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Spec.BucketName).To(Equal("bucket"))
+
+			actual, err := backupEntries.Get(ctx, "entry", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Spec.BucketName).To(Equal("bucket"))
+		})
+
+		It("should merge the applied fields into an existing backupEntry", func() {
+			backupEntry.Annotations = map[string]string{"foo": "bar"}
+			_, err := backupEntries.Create(ctx, backupEntry, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			update := backupEntry.DeepCopy()
+			update.Annotations = nil
+			update.Spec.BucketName = "other-bucket"
+
+			result, err := backupEntries.Apply(ctx, update, metav1.ApplyOptions{FieldManager: "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Spec.BucketName).To(Equal("other-bucket"))
+			Expect(result.Annotations).To(HaveKeyWithValue("foo", "bar"))
+		})
+
+		It("should fail if no name is provided", func() {
+			backupEntry.Name = ""
+			_, err := backupEntries.Apply(ctx, backupEntry, metav1.ApplyOptions{FieldManager: "test"})
+			Expect(err).To(MatchError(ContainSubstring("backupEntry.Name must be provided")))
+		})
+	})
+
+	Describe("#RecordedActions", func() {
+		It("should record exactly one patch for a status update", func() {
+			_, err := backupEntries.Create(ctx, backupEntry, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			update := backupEntry.DeepCopy()
+			update.Status.ObservedGeneration = 7
+			_, err = backupEntries.ApplyStatus(ctx, update, metav1.ApplyOptions{FieldManager: "test"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(backupEntries.RecordedActions("patch")).To(HaveLen(1))
+			Expect(backupEntries.RecordedActions("create")).To(HaveLen(1))
+			Expect(backupEntries.RecordedActions("update")).To(BeEmpty())
+		})
+
+		It("should filter actions by name", func() {
+			_, err := backupEntries.Create(ctx, backupEntry, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = backupEntries.Get(ctx, "entry", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(backupEntries.RecordedActionsForName("get", "entry")).To(HaveLen(1))
+			Expect(backupEntries.RecordedActionsForName("get", "other")).To(BeEmpty())
+		})
+	})
+
+	Describe("#ApplyStatus", func() {
+		It("should apply the status of an existing backupEntry", func() {
+			_, err := backupEntries.Create(ctx, backupEntry, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			update := backupEntry.DeepCopy()
+			update.Status.ObservedGeneration = 42
+
+			result, err := backupEntries.ApplyStatus(ctx, update, metav1.ApplyOptions{FieldManager: "test"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Status.ObservedGeneration).To(Equal(int64(42)))
+		})
+	})
+})
@@ -20,6 +20,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -109,7 +110,7 @@ func ReconcileSecretETCDEncryptionConfiguration(
 		}},
 	}
 
-	data, err := runtime.Encode(encryptionCodec, encryptionConfiguration)
+	data, err := encodeEncryptionConfiguration(encryptionConfiguration)
 	if err != nil {
 		return err
 	}
@@ -132,6 +133,27 @@ func ReconcileSecretETCDEncryptionConfiguration(
 	return c.Patch(ctx, secretETCDEncryptionConfiguration, patch)
 }
 
+// encodeEncryptionConfiguration encodes the given encryption configuration and validates that the result parses back
+// into an identical configuration. This catches serialization issues before the configuration is handed over to the
+// API server.
+func encodeEncryptionConfiguration(encryptionConfiguration *apiserverconfigv1.EncryptionConfiguration) ([]byte, error) {
+	data, err := runtime.Encode(encryptionCodec, encryptionConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	roundTripped := &apiserverconfigv1.EncryptionConfiguration{}
+	if err := runtime.DecodeInto(encryptionCodec, data, roundTripped); err != nil {
+		return nil, fmt.Errorf("encoded ETCD encryption configuration cannot be decoded again: %w", err)
+	}
+
+	if !apiequality.Semantic.DeepEqual(encryptionConfiguration.Resources, roundTripped.Resources) {
+		return nil, fmt.Errorf("ETCD encryption configuration does not survive an encoding round-trip, got %+v after decoding but expected %+v", roundTripped.Resources, encryptionConfiguration.Resources)
+	}
+
+	return data, nil
+}
+
 func etcdEncryptionAESKeys(keySecretCurrent, keySecretOld *corev1.Secret, encryptWithCurrentKey bool) []apiserverconfigv1.Key {
 	if keySecretOld == nil {
 		return []apiserverconfigv1.Key{
@@ -199,6 +199,14 @@ resources:
 			Entry("encrypting with current", true),
 			Entry("encrypting with old", false),
 		)
+
+		It("should fail when the encryption configuration does not survive an encoding round-trip", func() {
+			// the invalid UTF-8 byte is replaced during encoding, hence the decoded configuration differs
+			config.Resources = []string{"foo\xff"}
+
+			secretETCDEncryptionConfiguration := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "apiserver-encryption-config", Namespace: namespace}}
+			Expect(ReconcileSecretETCDEncryptionConfiguration(ctx, fakeClient, fakeSecretManager, config, secretETCDEncryptionConfiguration, secretNameETCDEncryptionKey, encryptionRoleLabel)).To(MatchError(ContainSubstring("does not survive an encoding round-trip")))
+		})
 	})
 
 	Describe("#InjectEncryptionSettings", func() {
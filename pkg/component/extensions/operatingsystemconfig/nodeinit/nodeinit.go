@@ -19,9 +19,12 @@ import (
 	_ "embed"
 	"fmt"
 	"html/template"
+	"path"
+	"strings"
 
 	"k8s.io/utils/pointer"
 
+	"github.com/gardener/gardener/extensions/pkg/controller/operatingsystemconfig"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/component/extensions/operatingsystemconfig/original/components/nodeagent"
@@ -29,7 +32,38 @@ import (
 	"github.com/gardener/gardener/pkg/utils"
 )
 
-const pathInitScript = nodeagentv1alpha1.BaseDir + "/init.sh"
+const initScriptFileName = "init.sh"
+
+// ImageVerification configures signature verification of the gardener-node-agent image before it is bootstrapped.
+// When configured, the init script verifies the pulled image (which should be pinned by digest) against the public key
+// and fails the bootstrap if the verification does not succeed.
+type ImageVerification struct {
+	// PublicKey is the PEM-encoded public key the image signature is verified with.
+	PublicKey string
+}
+
+// ExtraEnvironmentFile describes an additional environment file referenced by the gardener-node-init unit. It can be
+// used to inject secret environment variables (e.g., registry pull credentials) into the init unit without embedding
+// them into the init script itself.
+type ExtraEnvironmentFile struct {
+	// Path is the path on the machine the environment file is written to.
+	Path string
+	// Content is the content of the environment file (KEY=value pairs, one per line).
+	Content string
+}
+
+// OverflowSecret configures size-budget overflow handling for the node init payload. When the total size of the
+// inline file contents exceeds SizeBudget, the contents of the largest files are moved into the referenced secret
+// (which the gardener-node-agent fetches after it has been bootstrapped) until the payload fits the budget again.
+type OverflowSecret struct {
+	// Name is the name of the secret the overflowing file contents are moved to.
+	Name string
+	// SizeBudget is the maximum total size in bytes of the inline file contents shipped with the node init payload.
+	SizeBudget int
+	// Data contains the file contents which have been moved out of the payload, keyed by the data key the
+	// corresponding file references. It is populated by Config, and the caller must create the secret from it.
+	Data map[string][]byte
+}
 
 // Config returns the init units and the files for the OperatingSystemConfig for bootstrapping the gardener-node-agent.
 // ### !CAUTION! ###
@@ -40,17 +74,35 @@ const pathInitScript = nodeagentv1alpha1.BaseDir + "/init.sh"
 func Config(
 	worker gardencorev1beta1.Worker,
 	nodeAgentImage string,
+	imageVerification *ImageVerification,
 	config *nodeagentv1alpha1.NodeAgentConfiguration,
+	configFormat nodeagent.ConfigFormat,
+	overflowSecret *OverflowSecret,
+	keepImageMountForDebugging bool,
+	extraEnvironmentFiles ...ExtraEnvironmentFile,
 ) (
 	[]extensionsv1alpha1.Unit,
 	[]extensionsv1alpha1.File,
 	error,
 ) {
-	initScript, err := generateInitScript(nodeAgentImage)
+	initScript, err := generateInitScript(nodeAgentImage, imageVerification, configFormat, keepImageMountForDebugging)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed generating init script: %w", err)
 	}
 
+	// the init script must land in the same state directory the gardener-node-agent is configured with so that all
+	// state files end up under a common root
+	stateDir := config.StateDir
+	if stateDir == "" {
+		stateDir = nodeagentv1alpha1.BaseDir
+	}
+	pathInitScript := path.Join(stateDir, initScriptFileName)
+
+	environmentFileDirectives := "EnvironmentFile=/etc/environment"
+	for _, envFile := range extraEnvironmentFiles {
+		environmentFileDirectives += "\nEnvironmentFile=" + envFile.Path
+	}
+
 	var (
 		nodeInitUnits = []extensionsv1alpha1.Unit{{
 			Name:    nodeagentv1alpha1.InitUnitName,
@@ -65,7 +117,7 @@ Type=oneshot
 Restart=on-failure
 RestartSec=5
 StartLimitBurst=0
-EnvironmentFile=/etc/environment
+` + environmentFileDirectives + `
 ExecStart=` + pathInitScript + `
 [Install]
 WantedBy=multi-user.target`),
@@ -97,6 +149,21 @@ WantedBy=multi-user.target`),
 		}}
 	)
 
+	// The environment files may contain secret values, so they must only be readable by root and are tied to the init
+	// unit rather than shipped as global files.
+	for _, envFile := range extraEnvironmentFiles {
+		nodeInitUnits[0].Files = append(nodeInitUnits[0].Files, extensionsv1alpha1.File{
+			Path:        envFile.Path,
+			Permissions: pointer.Int32(0600),
+			Content: extensionsv1alpha1.FileContent{
+				Inline: &extensionsv1alpha1.FileContentInline{
+					Encoding: "b64",
+					Data:     utils.EncodeBase64([]byte(envFile.Content)),
+				},
+			},
+		})
+	}
+
 	// The gardener-node-init script above will bootstrap the gardener-node-agent. This means that the unit file for
 	// the gardener-node-agent unit will be written and eventually started (whilst gardener-node-init disables and stops
 	// itself). Hence, the files for gardener-node-agent (component configuration and kubeconfig) must be present on the
@@ -107,15 +174,64 @@ WantedBy=multi-user.target`),
 		return nil, nil, fmt.Errorf("failed computing bootstrap configuration: %w", err)
 	}
 
-	nodeAgentFiles, err := nodeagent.Files(config)
+	nodeAgentFiles, err := nodeagent.FilesForFormat(config, configFormat)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed computing gardener-node-agent files: %w", err)
 	}
 	nodeInitFiles = append(nodeInitFiles, nodeAgentFiles...)
 
+	if overflowSecret != nil {
+		if err := moveOverflowingFiles(nodeInitUnits, nodeInitFiles, overflowSecret); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return nodeInitUnits, nodeInitFiles, nil
 }
 
+// moveOverflowingFiles moves the contents of the largest inline files into the overflow secret until the estimated
+// size of the rendered node init scripts fits the configured budget. Files which must be shipped inline (i.e., the
+// bootstrap token file whose placeholder is substituted in the user data) are never moved.
+func moveOverflowingFiles(units []extensionsv1alpha1.Unit, files []extensionsv1alpha1.File, overflowSecret *OverflowSecret) error {
+	if overflowSecret.Data == nil {
+		overflowSecret.Data = map[string][]byte{}
+	}
+
+	for operatingsystemconfig.EstimateScriptSize(files, units) > overflowSecret.SizeBudget {
+		largest := -1
+		for i, file := range files {
+			if file.Content.Inline == nil || file.Path == nodeagentv1alpha1.BootstrapTokenFilePath {
+				continue
+			}
+			if largest == -1 || len(file.Content.Inline.Data) > len(files[largest].Content.Inline.Data) {
+				largest = i
+			}
+		}
+		if largest == -1 {
+			return fmt.Errorf("cannot fit the node init payload into the size budget of %d bytes", overflowSecret.SizeBudget)
+		}
+
+		content := []byte(files[largest].Content.Inline.Data)
+		if files[largest].Content.Inline.Encoding == "b64" {
+			var err error
+			content, err = utils.DecodeBase64(files[largest].Content.Inline.Data)
+			if err != nil {
+				return fmt.Errorf("failed decoding content of file %q: %w", files[largest].Path, err)
+			}
+		}
+
+		dataKey := strings.ReplaceAll(strings.TrimPrefix(files[largest].Path, "/"), "/", "_")
+		overflowSecret.Data[dataKey] = content
+
+		files[largest].Content = extensionsv1alpha1.FileContent{SecretRef: &extensionsv1alpha1.FileContentSecretRef{
+			Name:    overflowSecret.Name,
+			DataKey: dataKey,
+		}}
+	}
+
+	return nil
+}
+
 var (
 	//go:embed templates/scripts/init.tpl.sh
 	initScriptTplContent string
@@ -126,12 +242,21 @@ func init() {
 	initScriptTpl = template.Must(template.New("init-script").Parse(initScriptTplContent))
 }
 
-func generateInitScript(nodeAgentImage string) ([]byte, error) {
+// generateInitScript renders the init script. With keepImageMountForDebugging, the unmount trap is omitted so that
+// operators can inspect the mounted gardener-node-agent image after a failed bootstrap.
+func generateInitScript(nodeAgentImage string, imageVerification *ImageVerification, configFormat nodeagent.ConfigFormat, keepImageMountForDebugging bool) ([]byte, error) {
+	var verificationPublicKey string
+	if imageVerification != nil {
+		verificationPublicKey = imageVerification.PublicKey
+	}
+
 	var initScript bytes.Buffer
 	if err := initScriptTpl.Execute(&initScript, map[string]interface{}{
-		"image":           nodeAgentImage,
-		"binaryDirectory": nodeagentv1alpha1.BinaryDir,
-		"configFile":      nodeagentv1alpha1.ConfigFilePath,
+		"image":                      nodeAgentImage,
+		"binaryDirectory":            nodeagentv1alpha1.BinaryDir,
+		"configFile":                 nodeagent.ConfigPathForFormat(configFormat),
+		"verificationPublicKey":      verificationPublicKey,
+		"keepImageMountForDebugging": keepImageMountForDebugging,
 	}); err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ package nodeinit_test
 
 import (
 	"context"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -55,7 +56,7 @@ var _ = Describe("Init", func() {
 
 		When("kubelet data volume is not configured", func() {
 			It("should return the expected units and files", func() {
-				units, files, err := Config(worker, image, config)
+				units, files, err := Config(worker, image, nil, config, "", nil, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				Expect(units).To(ConsistOf(extensionsv1alpha1.Unit{
@@ -149,6 +150,113 @@ server: {}
 			})
 		})
 
+		When("extra environment files are configured", func() {
+			It("should reference them in the init unit and emit them with tight permissions", func() {
+				units, _, err := Config(worker, image, nil, config, "", nil, false, ExtraEnvironmentFile{
+					Path:    "/var/lib/gardener-node-agent/credentials/registry-env",
+					Content: "REGISTRY_USERNAME=user\nREGISTRY_PASSWORD=pass",
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(units).To(HaveLen(1))
+				Expect(*units[0].Content).To(ContainSubstring(`EnvironmentFile=/etc/environment
+EnvironmentFile=/var/lib/gardener-node-agent/credentials/registry-env
+ExecStart=`))
+				Expect(units[0].Files).To(ContainElement(extensionsv1alpha1.File{
+					Path:        "/var/lib/gardener-node-agent/credentials/registry-env",
+					Permissions: pointer.Int32(0600),
+					Content: extensionsv1alpha1.FileContent{
+						Inline: &extensionsv1alpha1.FileContentInline{
+							Encoding: "b64",
+							Data:     utils.EncodeBase64([]byte("REGISTRY_USERNAME=user\nREGISTRY_PASSWORD=pass")),
+						},
+					},
+				}))
+			})
+		})
+
+		When("image verification is configured", func() {
+			It("should emit a verification step in the init script", func() {
+				digestPinnedImage := "gna-repo@sha256:9efff1595f48ef0a41d08e34e0baeb31d8b3b1a24b6b0f1a77fb0cd5f1a76bc8"
+
+				units, _, err := Config(worker, digestPinnedImage, &ImageVerification{PublicKey: "gna-public-key"}, config, "", nil, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(units).To(HaveLen(1))
+				Expect(units[0].Files).To(HaveLen(1))
+
+				initScript, err := utils.DecodeBase64(units[0].Files[0].Content.Inline.Data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(initScript)).To(ContainSubstring(`ctr images pull  "` + digestPinnedImage + `" --hosts-dir "/etc/containerd/certs.d"
+
+echo "> Verify gardener-node-agent image signature"
+verification_key_file="$(mktemp)"
+printf '%s' "gna-public-key" > "$verification_key_file"
+ctr images verify --key "$verification_key_file" "` + digestPinnedImage + `"
+rm -f "$verification_key_file"
+ctr images mount "` + digestPinnedImage + `" "$tmp_dir"`))
+			})
+		})
+
+		When("the component config is rendered as JSON", func() {
+			It("should write the config to a .json path and point the init script to it", func() {
+				units, files, err := Config(worker, image, nil, config, nodeagentcomponent.ConfigFormatJSON, nil, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				initScript, err := utils.DecodeBase64(units[0].Files[0].Content.Inline.Data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(initScript)).To(ContainSubstring(`--config="/var/lib/gardener-node-agent/config.json"`))
+
+				var paths []string
+				for _, file := range files {
+					paths = append(paths, file.Path)
+				}
+				Expect(paths).To(ContainElement("/var/lib/gardener-node-agent/config.json"))
+				Expect(paths).NotTo(ContainElement("/var/lib/gardener-node-agent/config.yaml"))
+			})
+		})
+
+		When("the image mount shall be kept for debugging", func() {
+			It("should omit the unmount trap from the init script", func() {
+				units, _, err := Config(worker, image, nil, config, "", nil, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				initScript, err := utils.DecodeBase64(units[0].Files[0].Content.Inline.Data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(initScript)).NotTo(ContainSubstring("trap 'ctr images unmount"))
+				Expect(string(initScript)).To(ContainSubstring(`tmp_dir="$(mktemp -d)"`))
+			})
+		})
+
+		When("an overflow secret is configured", func() {
+			It("should move oversized file contents into the secret to stay within the size budget", func() {
+				config.APIServer.CABundle = []byte(strings.Repeat("a", 6000))
+				overflowSecret := &OverflowSecret{Name: "node-init-overflow", SizeBudget: 4096}
+
+				_, files, err := Config(worker, image, nil, config, "", overflowSecret, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(files).To(ContainElement(extensionsv1alpha1.File{
+					Path:        "/var/lib/gardener-node-agent/config.yaml",
+					Permissions: pointer.Int32(0600),
+					Content: extensionsv1alpha1.FileContent{SecretRef: &extensionsv1alpha1.FileContentSecretRef{
+						Name:    "node-init-overflow",
+						DataKey: "var_lib_gardener-node-agent_config.yaml",
+					}},
+				}))
+				Expect(overflowSecret.Data).To(HaveKey("var_lib_gardener-node-agent_config.yaml"))
+				Expect(string(overflowSecret.Data["var_lib_gardener-node-agent_config.yaml"])).To(ContainSubstring("apiVersion: nodeagent.config.gardener.cloud/v1alpha1"))
+
+				totalSize := 0
+				for _, file := range files {
+					if file.Content.Inline != nil {
+						totalSize += len(file.Content.Inline.Data)
+					}
+				}
+				Expect(totalSize).To(BeNumerically("<=", 4096))
+			})
+		})
+
 		When("kubelet data volume is configured", func() {
 			BeforeEach(func() {
 				worker.KubeletDataVolumeName = pointer.String("kubelet-data-vol")
@@ -161,14 +269,14 @@ server: {}
 			It("should return an error when the data volume cannot be found", func() {
 				*worker.KubeletDataVolumeName = "not-found"
 
-				units, files, err := Config(worker, image, config)
+				units, files, err := Config(worker, image, nil, config, "", nil, false)
 				Expect(err).To(MatchError(ContainSubstring("failed finding data volume for kubelet in worker with name")))
 				Expect(units).To(BeNil())
 				Expect(files).To(BeNil())
 			})
 
 			It("should correctly configure the bootstrap configuration", func() {
-				_, files, err := Config(worker, image, config)
+				_, files, err := Config(worker, image, nil, config, "", nil, false)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(files).To(ContainElement(extensionsv1alpha1.File{
 					Path:        "/var/lib/gardener-node-agent/config.yaml",
@@ -201,7 +309,7 @@ server: {}
 			})
 
 			It("should ensure the size of the configuration is not exceeding a certain limit", func() {
-				units, files, err := Config(worker, image, config)
+				units, files, err := Config(worker, image, nil, config, "", nil, false)
 				Expect(err).NotTo(HaveOccurred())
 
 				writeFilesToDiskScript, err := operatingsystemconfig.FilesToDiskScript(context.Background(), nil, "", files)
@@ -6,10 +6,20 @@ set -o pipefail
 
 echo "> Prepare temporary directory for image pull and mount"
 tmp_dir="$(mktemp -d)"
+{{- if not .keepImageMountForDebugging }}
 trap 'ctr images unmount "$tmp_dir" && rm -rf "$tmp_dir"' EXIT
+{{- end }}
 
 echo "> Pull gardener-node-agent image and mount it to the temporary directory"
 ctr images pull  "{{ .image }}" --hosts-dir "/etc/containerd/certs.d"
+{{- if .verificationPublicKey }}
+
+echo "> Verify gardener-node-agent image signature"
+verification_key_file="$(mktemp)"
+printf '%s' "{{ .verificationPublicKey }}" > "$verification_key_file"
+ctr images verify --key "$verification_key_file" "{{ .image }}"
+rm -f "$verification_key_file"
+{{- end }}
 ctr images mount "{{ .image }}" "$tmp_dir"
 
 echo "> Copy gardener-node-agent binary to host ({{ .binaryDirectory }}) and make it executable"
@@ -16,6 +16,7 @@ package nodeagent
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,15 +38,30 @@ import (
 // AccessSecretName is a constant for the secret name for the gardener-node-agent's shoot access secret.
 const AccessSecretName = "gardener-node-agent"
 
-var codec runtime.Codec
+// ConfigFormat is the serialization format of the gardener-node-agent component config file.
+type ConfigFormat string
+
+const (
+	// ConfigFormatYAML renders the component config as YAML (the default).
+	ConfigFormatYAML ConfigFormat = "yaml"
+	// ConfigFormatJSON renders the component config as JSON.
+	ConfigFormatJSON ConfigFormat = "json"
+)
+
+var (
+	codec     runtime.Codec
+	jsonCodec runtime.Codec
+)
 
 func init() {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(nodeagentv1alpha1.AddToScheme(scheme))
 
 	ser := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true, Pretty: false, Strict: false})
+	jsonSer := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: false, Pretty: false, Strict: false})
 	versions := schema.GroupVersions([]schema.GroupVersion{nodeagentv1alpha1.SchemeGroupVersion})
 	codec = serializer.NewCodecFactory(scheme).CodecForVersions(ser, ser, versions, versions)
+	jsonCodec = serializer.NewCodecFactory(scheme).CodecForVersions(jsonSer, jsonSer, versions, versions)
 }
 
 type component struct{}
@@ -129,15 +145,41 @@ func ComponentConfig(
 	}
 }
 
+// ConfigPathForFormat returns the file path on the worker node the component config is written to for the given
+// format. An empty format defaults to YAML.
+func ConfigPathForFormat(format ConfigFormat) string {
+	if format == ConfigFormatJSON {
+		return strings.TrimSuffix(nodeagentv1alpha1.ConfigFilePath, ".yaml") + ".json"
+	}
+	return nodeagentv1alpha1.ConfigFilePath
+}
+
 // Files returns the files related to the gardener-node-agent unit.
 func Files(config *nodeagentv1alpha1.NodeAgentConfiguration) ([]extensionsv1alpha1.File, error) {
-	configRaw, err := runtime.Encode(codec, config)
+	return FilesForFormat(config, ConfigFormatYAML)
+}
+
+// FilesForFormat returns the files related to the gardener-node-agent unit with the component config rendered in the
+// given format. The file path carries the matching extension so that tooling can detect the format. An empty format
+// defaults to YAML. The gardener-node-agent accepts both formats when reading its config file.
+func FilesForFormat(config *nodeagentv1alpha1.NodeAgentConfiguration, format ConfigFormat) ([]extensionsv1alpha1.File, error) {
+	configCodec := codec
+
+	switch format {
+	case ConfigFormatYAML, "":
+	case ConfigFormatJSON:
+		configCodec = jsonCodec
+	default:
+		return nil, fmt.Errorf("unsupported component config format %q", format)
+	}
+
+	configRaw, err := runtime.Encode(configCodec, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed encoding component config: %w", err)
 	}
 
 	return []extensionsv1alpha1.File{{
-		Path:        nodeagentv1alpha1.ConfigFilePath,
+		Path:        ConfigPathForFormat(format),
 		Permissions: pointer.Int32(0600),
 		Content:     extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Encoding: "b64", Data: utils.EncodeBase64(configRaw)}},
 	}}, nil
@@ -15,6 +15,7 @@
 package nodeagent_test
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/Masterminds/semver/v3"
@@ -165,4 +166,32 @@ server: {}
 			}))
 		})
 	})
+
+	Describe("#FilesForFormat", func() {
+		It("should render the JSON variant such that it round-trips to the same config object", func() {
+			config := ComponentConfig(oscSecretName, kubernetesVersion, apiServerURL, caBundle, syncJitterPeriod)
+
+			files, err := FilesForFormat(config, ConfigFormatJSON)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+			Expect(files[0].Path).To(Equal("/var/lib/gardener-node-agent/config.json"))
+			Expect(files[0].Permissions).To(Equal(pointer.Int32(0600)))
+
+			configRaw, err := utils.DecodeBase64(files[0].Content.Inline.Data)
+			Expect(err).NotTo(HaveOccurred())
+
+			decodedConfig := &nodeagentv1alpha1.NodeAgentConfiguration{}
+			Expect(json.Unmarshal(configRaw, decodedConfig)).To(Succeed())
+			decodedConfig.TypeMeta = metav1.TypeMeta{}
+			Expect(decodedConfig).To(Equal(config))
+		})
+
+		It("should return an error for an unsupported format", func() {
+			config := ComponentConfig(oscSecretName, kubernetesVersion, apiServerURL, caBundle, syncJitterPeriod)
+
+			files, err := FilesForFormat(config, "toml")
+			Expect(err).To(MatchError(ContainSubstring("unsupported component config format")))
+			Expect(files).To(BeNil())
+		})
+	})
 })
@@ -17,6 +17,7 @@ package metricsserver
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
@@ -57,8 +58,15 @@ const (
 
 	volumeMountNameServer = "metrics-server"
 	volumeMountPathServer = "/srv/metrics-server/tls"
+
+	// labelKeyShard is the pod label distinguishing the shard Deployments in the experimental sharded mode.
+	labelKeyShard = "metrics-server-shard"
 )
 
+// NodeLabelShard is the label on Node objects whose value assigns the node to a metrics-server shard in the
+// experimental sharded mode. Nodes must be labeled with values in [0,shards) for all kubelets to be scraped.
+const NodeLabelShard = "node.gardener.cloud/metrics-server-shard"
+
 // New creates a new instance of DeployWaiter for the metrics-server.
 func New(
 	client client.Client,
@@ -82,6 +90,44 @@ type Values struct {
 	VPAEnabled bool
 	// KubeAPIServerHost is the kube-apiserver host name.
 	KubeAPIServerHost *string
+	// RegisterAPIService specifies whether the `v1beta1.metrics.k8s.io` APIService shall be part of the
+	// ManagedResource. When disabled, only the Deployment and Service are deployed so that operators can register
+	// their own metrics API.
+	RegisterAPIService bool
+	// APIServiceGroupPriorityMinimum is the groupPriorityMinimum of the `v1beta1.metrics.k8s.io` APIService.
+	// Defaults to 100 if not set.
+	APIServiceGroupPriorityMinimum *int32
+	// APIServiceVersionPriority is the versionPriority of the `v1beta1.metrics.k8s.io` APIService.
+	// Defaults to 100 if not set.
+	APIServiceVersionPriority *int32
+	// TerminationGracePeriodSeconds is the terminationGracePeriodSeconds of the metrics-server pods.
+	TerminationGracePeriodSeconds *int64
+	// PreStopSleepDuration is the duration the metrics-server container sleeps in a preStop hook before it is
+	// terminated. This gives the kube-apiserver time to deregister the APIService before the endpoint vanishes.
+	PreStopSleepDuration *metav1.Duration
+	// GOMAXPROCS limits the number of operating system threads executing Go code simultaneously in the metrics-server
+	// container by injecting the `GOMAXPROCS` environment variable. This is useful on large nodes where the container
+	// is CPU-limited. No environment variable is injected if not set.
+	GOMAXPROCS *int32
+	// Replicas is the number of replicas of the metrics-server Deployment. Defaults to 1 if not set.
+	Replicas *int32
+	// TopologySpreadConstraintTopologyKeys are the topology keys across which the metrics-server replicas shall be
+	// spread. Topology spread constraints are only applied when more than one replica is configured.
+	TopologySpreadConstraintTopologyKeys []string
+	// Shards is the number of metrics-server shard Deployments. When greater than 1, one Deployment per shard is
+	// rendered instead of a single Deployment, and every shard only scrapes the kubelets of the nodes labeled with the
+	// corresponding shard value (see NodeLabelShard). This mode is experimental and intended for very large clusters
+	// where a single metrics-server cannot keep up scraping all kubelets.
+	Shards *int32
+	// ExtraLabels are additional labels merged into the metadata of all objects created by this component. Labels
+	// already set by the component take precedence in case of conflicts.
+	ExtraLabels map[string]string
+	// ExtraAnnotations are additional annotations merged into the metadata of all objects created by this component.
+	// Annotations already set by the component take precedence in case of conflicts.
+	ExtraAnnotations map[string]string
+	// TargetNamespace is the namespace into which the namespaced shoot-side objects are rendered. Defaults to
+	// kube-system if not set. This is mainly useful for hosted-control-plane topologies and for testing.
+	TargetNamespace string
 }
 
 type metricsServer struct {
@@ -95,7 +141,7 @@ func (m *metricsServer) Deploy(ctx context.Context) error {
 	serverSecret, err := m.secretsManager.Generate(ctx, &secrets.CertificateSecretConfig{
 		Name:                        secretNameServer,
 		CommonName:                  "metrics-server",
-		DNSNames:                    append([]string{serviceName}, kubernetesutils.DNSNamesForService(serviceName, metav1.NamespaceSystem)...),
+		DNSNames:                    append([]string{serviceName}, kubernetesutils.DNSNamesForService(serviceName, m.targetNamespace())...),
 		CertType:                    secrets.ServerCert,
 		SkipPublishingCACertificate: true,
 	}, secretsmanager.SignedByCA(v1beta1constants.SecretNameCAMetricsServer, secretsmanager.UseCurrentCA), secretsmanager.Rotate(secretsmanager.InPlace))
@@ -103,7 +149,9 @@ func (m *metricsServer) Deploy(ctx context.Context) error {
 		return err
 	}
 
-	caSecret, found := m.secretsManager.Get(v1beta1constants.SecretNameCAMetricsServer)
+	// Use the CA bundle (and not only the current CA) so that the APIService keeps trusting both old and new CA
+	// during CA rotation.
+	caSecret, found := m.secretsManager.Get(v1beta1constants.SecretNameCAMetricsServer, secretsmanager.Bundle)
 	if !found {
 		return fmt.Errorf("secret %q not found", v1beta1constants.SecretNameCAMetricsServer)
 	}
@@ -117,17 +165,34 @@ func (m *metricsServer) Deploy(ctx context.Context) error {
 }
 
 func (m *metricsServer) Destroy(ctx context.Context) error {
-	return managedresources.DeleteForShoot(ctx, m.client, m.namespace, managedResourceName)
+	if err := managedresources.DeleteForShoot(ctx, m.client, m.namespace, managedResourceName); err != nil {
+		return err
+	}
+
+	// Also clean up the legacy metrics-server TLS secret which was managed directly in the seed namespace before the
+	// certificate was moved under the secrets manager. Deletion is idempotent, so partial prior state (e.g. a previous
+	// Destroy that was interrupted after the ManagedResource was removed) is tolerated.
+	return kubernetesutils.DeleteObject(ctx, m.client, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretNameServer, Namespace: m.namespace}})
 }
 
 func (m *metricsServer) Wait(_ context.Context) error        { return nil }
 func (m *metricsServer) WaitCleanup(_ context.Context) error { return nil }
 
+// targetNamespace returns the namespace into which the namespaced shoot-side objects are rendered.
+func (m *metricsServer) targetNamespace() string {
+	if m.values.TargetNamespace == "" {
+		return metav1.NamespaceSystem
+	}
+	return m.values.TargetNamespace
+}
+
 func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secret) (map[string][]byte, error) {
+	targetNamespace := m.targetNamespace()
+
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "metrics-server",
-			Namespace: metav1.NamespaceSystem,
+			Namespace: targetNamespace,
 		},
 		Type: corev1.SecretTypeTLS,
 		Data: serverSecret.Data,
@@ -140,7 +205,7 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		serviceAccount = &corev1.ServiceAccount{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      serviceAccountName,
-				Namespace: metav1.NamespaceSystem,
+				Namespace: targetNamespace,
 			},
 			AutomountServiceAccountToken: pointer.Bool(false),
 		}
@@ -199,7 +264,7 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		roleBinding = &rbacv1.RoleBinding{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "metrics-server-auth-reader",
-				Namespace: metav1.NamespaceSystem,
+				Namespace: targetNamespace,
 				Annotations: map[string]string{
 					resourcesv1alpha1.DeleteOnInvalidUpdate: "true",
 				},
@@ -219,7 +284,7 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		service = &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      serviceName,
-				Namespace: metav1.NamespaceSystem,
+				Namespace: targetNamespace,
 				Labels:    map[string]string{"kubernetes.io/name": serviceName},
 			},
 			Spec: corev1.ServiceSpec{
@@ -234,28 +299,13 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 			},
 		}
 
-		apiService = &apiregistrationv1.APIService{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "v1beta1.metrics.k8s.io",
-			},
-			Spec: apiregistrationv1.APIServiceSpec{
-				Service: &apiregistrationv1.ServiceReference{
-					Name:      service.Name,
-					Namespace: metav1.NamespaceSystem,
-				},
-				Group:                "metrics.k8s.io",
-				GroupPriorityMinimum: 100,
-				Version:              "v1beta1",
-				VersionPriority:      100,
-				CABundle:             caSecret.Data[secrets.DataKeyCertificateBundle],
-			},
-		}
+		apiService *apiregistrationv1.APIService
 
 		maxUnavailable = intstr.FromInt32(0)
 		deployment     = &appsv1.Deployment{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      deploymentName,
-				Namespace: metav1.NamespaceSystem,
+				Namespace: targetNamespace,
 				Labels: utils.MergeStringMaps(getLabels(), map[string]string{
 					managedresources.LabelKeyOrigin:              managedresources.LabelValueGardener,
 					v1beta1constants.GardenRole:                  v1beta1constants.GardenRoleSystemComponent,
@@ -368,7 +418,7 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		podDisruptionBudget = &policyv1.PodDisruptionBudget{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "metrics-server",
-				Namespace: metav1.NamespaceSystem,
+				Namespace: targetNamespace,
 				Labels:    getLabels(),
 			},
 			Spec: policyv1.PodDisruptionBudgetSpec{
@@ -380,6 +430,39 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		vpa *vpaautoscalingv1.VerticalPodAutoscaler
 	)
 
+	if m.values.RegisterAPIService {
+		apiService = &apiregistrationv1.APIService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "v1beta1.metrics.k8s.io",
+			},
+			Spec: apiregistrationv1.APIServiceSpec{
+				Service: &apiregistrationv1.ServiceReference{
+					Name:      service.Name,
+					Namespace: targetNamespace,
+				},
+				Group:                "metrics.k8s.io",
+				GroupPriorityMinimum: pointer.Int32Deref(m.values.APIServiceGroupPriorityMinimum, 100),
+				Version:              "v1beta1",
+				VersionPriority:      pointer.Int32Deref(m.values.APIServiceVersionPriority, 100),
+				CABundle:             caSecret.Data[secrets.DataKeyCertificateBundle],
+			},
+		}
+	}
+
+	if m.values.TerminationGracePeriodSeconds != nil {
+		deployment.Spec.Template.Spec.TerminationGracePeriodSeconds = m.values.TerminationGracePeriodSeconds
+	}
+
+	if m.values.PreStopSleepDuration != nil {
+		deployment.Spec.Template.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"/bin/sh", "-c", fmt.Sprintf("sleep %d", int64(m.values.PreStopSleepDuration.Seconds()))},
+				},
+			},
+		}
+	}
+
 	if m.values.KubeAPIServerHost != nil {
 		deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
 			Name:  "KUBERNETES_SERVICE_HOST",
@@ -387,6 +470,26 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		})
 	}
 
+	if m.values.GOMAXPROCS != nil {
+		deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+			Name:  "GOMAXPROCS",
+			Value: fmt.Sprintf("%d", *m.values.GOMAXPROCS),
+		})
+	}
+
+	if replicas := pointer.Int32Deref(m.values.Replicas, 1); replicas > 1 {
+		deployment.Spec.Replicas = pointer.Int32(replicas)
+
+		for _, topologyKey := range m.values.TopologySpreadConstraintTopologyKeys {
+			deployment.Spec.Template.Spec.TopologySpreadConstraints = append(deployment.Spec.Template.Spec.TopologySpreadConstraints, corev1.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       topologyKey,
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: getLabels()},
+			})
+		}
+	}
+
 	if m.values.VPAEnabled {
 		deployment.Spec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
 			corev1.ResourceCPU:    resource.MustParse("50m"),
@@ -398,7 +501,7 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		vpa = &vpaautoscalingv1.VerticalPodAutoscaler{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "metrics-server",
-				Namespace: metav1.NamespaceSystem,
+				Namespace: targetNamespace,
 			},
 			Spec: vpaautoscalingv1.VerticalPodAutoscalerSpec{
 				TargetRef: &autoscalingv1.CrossVersionObjectReference{
@@ -424,9 +527,7 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		}
 	}
 
-	utilruntime.Must(references.InjectAnnotations(deployment))
-
-	return registry.AddAllAndSerialize(
+	resources := []client.Object{
 		serviceAccount,
 		clusterRole,
 		clusterRoleBinding,
@@ -435,10 +536,53 @@ func (m *metricsServer) computeResourcesData(serverSecret, caSecret *corev1.Secr
 		secret,
 		service,
 		apiService,
-		deployment,
 		podDisruptionBudget,
-		vpa,
-	)
+	}
+
+	if shards := pointer.Int32Deref(m.values.Shards, 1); shards > 1 {
+		// the VPA is not rendered in sharded mode since its target Deployment does not exist
+		for i := int32(0); i < shards; i++ {
+			shardValue := fmt.Sprintf("%d", i)
+
+			shardDeployment := deployment.DeepCopy()
+			shardDeployment.Name = fmt.Sprintf("%s-%s", deploymentName, shardValue)
+			shardDeployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: utils.MergeStringMaps(getLabels(), map[string]string{labelKeyShard: shardValue})}
+			shardDeployment.Spec.Template.Labels = utils.MergeStringMaps(shardDeployment.Spec.Template.Labels, map[string]string{labelKeyShard: shardValue})
+			shardDeployment.Spec.Template.Spec.Containers[0].Command = append(shardDeployment.Spec.Template.Spec.Containers[0].Command,
+				fmt.Sprintf("--node-selector=%s=%s", NodeLabelShard, shardValue))
+
+			utilruntime.Must(references.InjectAnnotations(shardDeployment))
+			resources = append(resources, shardDeployment)
+		}
+	} else {
+		utilruntime.Must(references.InjectAnnotations(deployment))
+		resources = append(resources, deployment, vpa)
+	}
+
+	m.stampExtraMetadata(resources)
+
+	return registry.AddAllAndSerialize(resources...)
+}
+
+// stampExtraMetadata merges the configured extra labels and annotations into the metadata of all given objects.
+// Metadata already set by the component is not overwritten.
+func (m *metricsServer) stampExtraMetadata(objects []client.Object) {
+	if len(m.values.ExtraLabels) == 0 && len(m.values.ExtraAnnotations) == 0 {
+		return
+	}
+
+	for _, obj := range objects {
+		if obj == nil || reflect.ValueOf(obj).IsNil() {
+			continue
+		}
+
+		if len(m.values.ExtraLabels) > 0 {
+			obj.SetLabels(utils.MergeStringMaps(m.values.ExtraLabels, obj.GetLabels()))
+		}
+		if len(m.values.ExtraAnnotations) > 0 {
+			obj.SetAnnotations(utils.MergeStringMaps(m.values.ExtraAnnotations, obj.GetAnnotations()))
+		}
+	}
 }
 
 func getLabels() map[string]string {
@@ -16,17 +16,21 @@ package metricsserver_test
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -36,6 +40,7 @@ import (
 	"github.com/gardener/gardener/pkg/component"
 	. "github.com/gardener/gardener/pkg/component/metricsserver"
 	"github.com/gardener/gardener/pkg/resourcemanager/controller/garbagecollector/references"
+	secretsutils "github.com/gardener/gardener/pkg/utils/secrets"
 	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
 	fakesecretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager/fake"
 	. "github.com/gardener/gardener/pkg/utils/test/matchers"
@@ -327,12 +332,13 @@ status:
 		sm = fakesecretsmanager.New(fakeClient, namespace)
 
 		By("Create secrets managed outside of this package for whose secretsmanager.Get() will be called")
-		Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-metrics-server", Namespace: namespace}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-metrics-server-bundle", Namespace: namespace}})).To(Succeed())
 
 		values = Values{
-			Image:             image,
-			VPAEnabled:        false,
-			KubeAPIServerHost: nil,
+			Image:              image,
+			VPAEnabled:         false,
+			KubeAPIServerHost:  nil,
+			RegisterAPIService: true,
 		}
 
 		metricsServer = New(fakeClient, namespace, sm, values)
@@ -476,6 +482,201 @@ status:
 			Expect(secret.Data).NotTo(BeEmpty())
 			Expect(secret.Labels).To(HaveKeyWithValue("resources.gardener.cloud/garbage-collectable-reference", "true"))
 		})
+		It("should omit the APIService when its registration is disabled", func() {
+			values.RegisterAPIService = false
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+			Expect(managedResourceSecret.Data).To(HaveLen(9))
+			Expect(managedResourceSecret.Data).NotTo(HaveKey("apiservice____v1beta1.metrics.k8s.io.yaml"))
+			Expect(managedResourceSecret.Data).To(HaveKey("deployment__kube-system__metrics-server.yaml"))
+			Expect(managedResourceSecret.Data).To(HaveKey("service__kube-system__metrics-server.yaml"))
+		})
+
+		It("should render the termination grace settings when configured", func() {
+			values.TerminationGracePeriodSeconds = pointer.Int64(50)
+			values.PreStopSleepDuration = &metav1.Duration{Duration: 10 * time.Second}
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			deployment := &appsv1.Deployment{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["deployment__kube-system__metrics-server.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.TerminationGracePeriodSeconds).To(PointTo(Equal(int64(50))))
+			Expect(deployment.Spec.Template.Spec.Containers[0].Lifecycle).NotTo(BeNil())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Lifecycle.PreStop.Exec.Command).To(Equal([]string{"/bin/sh", "-c", "sleep 10"}))
+		})
+
+		It("should render the GOMAXPROCS environment variable when configured", func() {
+			values.GOMAXPROCS = pointer.Int32(4)
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			deployment := &appsv1.Deployment{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["deployment__kube-system__metrics-server.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "GOMAXPROCS", Value: "4"}))
+		})
+
+		It("should spread multiple replicas across the configured topology keys", func() {
+			values.Replicas = pointer.Int32(2)
+			values.TopologySpreadConstraintTopologyKeys = []string{"topology.kubernetes.io/zone"}
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			deployment := &appsv1.Deployment{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["deployment__kube-system__metrics-server.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Spec.Replicas).To(PointTo(Equal(int32(2))))
+			Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints).To(ConsistOf(corev1.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+				LabelSelector:     deployment.Spec.Selector,
+			}))
+		})
+
+		It("should omit topology spread constraints for a single replica", func() {
+			values.TopologySpreadConstraintTopologyKeys = []string{"topology.kubernetes.io/zone"}
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			deployment := &appsv1.Deployment{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["deployment__kube-system__metrics-server.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Spec.Replicas).To(PointTo(Equal(int32(1))))
+			Expect(deployment.Spec.Template.Spec.TopologySpreadConstraints).To(BeEmpty())
+		})
+
+		It("should render one Deployment per shard with disjoint selectors in the experimental sharded mode", func() {
+			values.Shards = pointer.Int32(3)
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			Expect(managedResourceSecret.Data).NotTo(HaveKey("deployment__kube-system__metrics-server.yaml"))
+
+			seenShardValues := map[string]struct{}{}
+			for i := 0; i < 3; i++ {
+				key := fmt.Sprintf("deployment__kube-system__metrics-server-%d.yaml", i)
+				Expect(managedResourceSecret.Data).To(HaveKey(key))
+
+				deployment := &appsv1.Deployment{}
+				Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data[key], deployment)).To(Succeed())
+
+				shardValue := deployment.Spec.Selector.MatchLabels["metrics-server-shard"]
+				Expect(shardValue).To(Equal(fmt.Sprintf("%d", i)))
+				Expect(seenShardValues).NotTo(HaveKey(shardValue), "shard selectors must be disjoint")
+				seenShardValues[shardValue] = struct{}{}
+
+				Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue("metrics-server-shard", shardValue))
+				Expect(deployment.Spec.Template.Spec.Containers[0].Command).To(ContainElement(fmt.Sprintf("--node-selector=%s=%s", NodeLabelShard, shardValue)))
+			}
+		})
+
+		It("should stamp the configured extra labels and annotations on the created objects", func() {
+			values.ExtraLabels = map[string]string{"cost-center": "observability", "k8s-app": "must-not-win"}
+			values.ExtraAnnotations = map[string]string{"policy.example.com/audited": "true"}
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			service := &corev1.Service{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["service__kube-system__metrics-server.yaml"], service)).To(Succeed())
+			Expect(service.Labels).To(HaveKeyWithValue("cost-center", "observability"))
+			Expect(service.Labels).To(HaveKeyWithValue("kubernetes.io/name", "metrics-server"))
+			Expect(service.Annotations).To(HaveKeyWithValue("policy.example.com/audited", "true"))
+
+			deployment := &appsv1.Deployment{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["deployment__kube-system__metrics-server.yaml"], deployment)).To(Succeed())
+			Expect(deployment.Labels).To(HaveKeyWithValue("cost-center", "observability"))
+			Expect(deployment.Labels).To(HaveKeyWithValue("k8s-app", "metrics-server"), "component labels must not be clobbered")
+			Expect(deployment.Annotations).To(HaveKeyWithValue("policy.example.com/audited", "true"))
+		})
+
+		It("should render the shoot-core objects into a custom target namespace", func() {
+			values.TargetNamespace = "custom-system"
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			Expect(managedResourceSecret.Data).To(HaveKey("deployment__custom-system__metrics-server.yaml"))
+			Expect(managedResourceSecret.Data).To(HaveKey("service__custom-system__metrics-server.yaml"))
+			Expect(managedResourceSecret.Data).To(HaveKey("serviceaccount__custom-system__metrics-server.yaml"))
+			Expect(managedResourceSecret.Data).To(HaveKey("rolebinding__custom-system__metrics-server-auth-reader.yaml"))
+			Expect(managedResourceSecret.Data).NotTo(HaveKey("deployment__kube-system__metrics-server.yaml"))
+
+			apiService := &apiregistrationv1.APIService{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["apiservice____v1beta1.metrics.k8s.io.yaml"], apiService)).To(Succeed())
+			Expect(apiService.Spec.Service.Namespace).To(Equal("custom-system"))
+		})
+
+		It("should include the full CA bundle in the APIService during CA rotation", func() {
+			caBundle := []byte("-----BEGIN CERTIFICATE-----\nold-ca\n-----END CERTIFICATE-----\n-----BEGIN CERTIFICATE-----\nnew-ca\n-----END CERTIFICATE-----\n")
+			caBundleSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca-metrics-server-bundle", Namespace: namespace}}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(caBundleSecret), caBundleSecret)).To(Succeed())
+			caBundleSecret.Data = map[string][]byte{secretsutils.DataKeyCertificateBundle: caBundle}
+			Expect(fakeClient.Update(ctx, caBundleSecret)).To(Succeed())
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			apiService := &apiregistrationv1.APIService{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["apiservice____v1beta1.metrics.k8s.io.yaml"], apiService)).To(Succeed())
+			Expect(apiService.Spec.CABundle).To(Equal(caBundle))
+		})
+
+		It("should render the APIService with the configured priorities", func() {
+			values.APIServiceGroupPriorityMinimum = pointer.Int32(50)
+			values.APIServiceVersionPriority = pointer.Int32(200)
+			metricsServer = New(fakeClient, namespace, sm, values)
+
+			Expect(metricsServer.Deploy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(Succeed())
+			managedResourceSecret.Name = managedResource.Spec.SecretRefs[0].Name
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(Succeed())
+
+			apiService := &apiregistrationv1.APIService{}
+			Expect(runtime.DecodeInto(newCodec(), managedResourceSecret.Data["apiservice____v1beta1.metrics.k8s.io.yaml"], apiService)).To(Succeed())
+			Expect(apiService.Spec.GroupPriorityMinimum).To(Equal(int32(50)))
+			Expect(apiService.Spec.VersionPriority).To(Equal(int32(200)))
+		})
 	})
 
 	Describe("#Destroy", func() {
@@ -488,6 +689,20 @@ status:
 			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResource), managedResource)).To(MatchError(apierrors.NewNotFound(schema.GroupResource{Group: resourcesv1alpha1.SchemeGroupVersion.Group, Resource: "managedresources"}, managedResource.Name)))
 			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(managedResourceSecret), managedResourceSecret)).To(MatchError(apierrors.NewNotFound(schema.GroupResource{Group: corev1.SchemeGroupVersion.Group, Resource: "secrets"}, managedResourceSecret.Name)))
 		})
+
+		It("should remove a stray legacy TLS secret", func() {
+			straySecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "metrics-server", Namespace: namespace}}
+			Expect(fakeClient.Create(ctx, straySecret)).To(Succeed())
+
+			Expect(metricsServer.Destroy(ctx)).To(Succeed())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(straySecret), straySecret)).To(BeNotFoundError())
+		})
+
+		It("should succeed when nothing is left to delete", func() {
+			Expect(metricsServer.Destroy(ctx)).To(Succeed())
+			Expect(metricsServer.Destroy(ctx)).To(Succeed())
+		})
 	})
 
 	Describe("#Wait", func() {
@@ -252,6 +252,53 @@ type ShootMaintenanceControllerConfiguration struct {
 	EnableShootControlPlaneRestarter *bool
 	// EnableShootCoreAddonRestarter configures whether some core addons to be restarted during maintenance.
 	EnableShootCoreAddonRestarter *bool
+	// MaxDuration is the maximum duration a maintenance operation may stay in 'Processing' state before the
+	// controller marks it as failed and retries it in the next maintenance window.
+	MaxDuration *metav1.Duration
+	// MaxConcurrentPerSeed is the maximum number of maintenance operations running concurrently for Shoots scheduled
+	// to the same seed. Maintenance of further Shoots is delayed until a slot becomes free again.
+	MaxConcurrentPerSeed *int32
+	// NotificationWebhookURL is the URL of a webhook which is notified with a JSON payload whenever a maintenance
+	// operation forcefully updated a version because it expired.
+	NotificationWebhookURL *string
+	// MinDisruptiveUpdateInterval is the minimum interval between disruptive (minor or major) version updates of
+	// the same Shoot, measured from the TriggeredTime of its last maintenance. Disruptive updates within the
+	// interval are deferred to a subsequent maintenance window while patch-level updates remain unaffected.
+	// If unset, disruptive updates are not spaced out.
+	MinDisruptiveUpdateInterval *metav1.Duration
+	// FeatureGates is a map of maintenance feature names to bools enabling or disabling individual maintenance
+	// sub-behaviors. All gates default to off.
+	FeatureGates map[string]bool
+	// FreezeWindows is a list of absolute time ranges during which no maintenance updates are applied to any Shoot
+	// ("change freeze"). Force updates of truly expired versions are only applied during a freeze window when the
+	// window explicitly allows them.
+	FreezeWindows []MaintenanceFreezeWindow
+	// DefaultTimeWindow is the maintenance time window applied to Shoots which do not declare an own time window in
+	// their specification. If unset, such Shoots can be maintained at any time.
+	DefaultTimeWindow *MaintenanceTimeWindow
+	// MinTimeWindowDuration is the minimum duration a Shoot's maintenance time window must last. Shoots declaring a
+	// shorter window are not maintained and the reason is recorded in their maintenance status. If unset, windows of
+	// any duration are accepted.
+	MinTimeWindowDuration *metav1.Duration
+}
+
+// MaintenanceTimeWindow defines a daily time window in the format of the Shoot's maintenance time window.
+type MaintenanceTimeWindow struct {
+	// Begin is the beginning of the time window in the format "HHMMSS+ZONE".
+	Begin string
+	// End is the end of the time window in the format "HHMMSS+ZONE".
+	End string
+}
+
+// MaintenanceFreezeWindow defines an absolute time range during which no maintenance updates are applied.
+type MaintenanceFreezeWindow struct {
+	// Begin is the start of the freeze window.
+	Begin metav1.Time
+	// End is the end of the freeze window.
+	End metav1.Time
+	// AllowExpiredForceUpdates specifies whether force updates of truly expired versions are still applied during
+	// the freeze window.
+	AllowExpiredForceUpdates bool
 }
 
 // ShootQuotaControllerConfiguration defines the configuration of the
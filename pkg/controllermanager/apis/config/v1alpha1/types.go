@@ -305,6 +305,62 @@ type ShootMaintenanceControllerConfiguration struct {
 	// EnableShootCoreAddonRestarter configures whether some core addons to be restarted during maintenance.
 	// +optional
 	EnableShootCoreAddonRestarter *bool `json:"enableShootCoreAddonRestarter"`
+	// MaxDuration is the maximum duration a maintenance operation may stay in 'Processing' state before the
+	// controller marks it as failed and retries it in the next maintenance window.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+	// MaxConcurrentPerSeed is the maximum number of maintenance operations running concurrently for Shoots scheduled
+	// to the same seed. Maintenance of further Shoots is delayed until a slot becomes free again.
+	// +optional
+	MaxConcurrentPerSeed *int32 `json:"maxConcurrentPerSeed,omitempty"`
+	// NotificationWebhookURL is the URL of a webhook which is notified with a JSON payload whenever a maintenance
+	// operation forcefully updated a version because it expired.
+	// +optional
+	NotificationWebhookURL *string `json:"notificationWebhookURL,omitempty"`
+	// MinDisruptiveUpdateInterval is the minimum interval between disruptive (minor or major) version updates of
+	// the same Shoot, measured from the TriggeredTime of its last maintenance. Disruptive updates within the
+	// interval are deferred to a subsequent maintenance window while patch-level updates remain unaffected.
+	// If unset, disruptive updates are not spaced out.
+	// +optional
+	MinDisruptiveUpdateInterval *metav1.Duration `json:"minDisruptiveUpdateInterval,omitempty"`
+	// FeatureGates is a map of maintenance feature names to bools enabling or disabling individual maintenance
+	// sub-behaviors. All gates default to off.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// FreezeWindows is a list of absolute time ranges during which no maintenance updates are applied to any Shoot
+	// ("change freeze"). Force updates of truly expired versions are only applied during a freeze window when the
+	// window explicitly allows them.
+	// +optional
+	FreezeWindows []MaintenanceFreezeWindow `json:"freezeWindows,omitempty"`
+	// DefaultTimeWindow is the maintenance time window applied to Shoots which do not declare an own time window in
+	// their specification. If unset, such Shoots can be maintained at any time.
+	// +optional
+	DefaultTimeWindow *MaintenanceTimeWindow `json:"defaultTimeWindow,omitempty"`
+	// MinTimeWindowDuration is the minimum duration a Shoot's maintenance time window must last. Shoots declaring a
+	// shorter window are not maintained and the reason is recorded in their maintenance status. If unset, windows of
+	// any duration are accepted.
+	// +optional
+	MinTimeWindowDuration *metav1.Duration `json:"minTimeWindowDuration,omitempty"`
+}
+
+// MaintenanceTimeWindow defines a daily time window in the format of the Shoot's maintenance time window.
+type MaintenanceTimeWindow struct {
+	// Begin is the beginning of the time window in the format "HHMMSS+ZONE".
+	Begin string `json:"begin"`
+	// End is the end of the time window in the format "HHMMSS+ZONE".
+	End string `json:"end"`
+}
+
+// MaintenanceFreezeWindow defines an absolute time range during which no maintenance updates are applied.
+type MaintenanceFreezeWindow struct {
+	// Begin is the start of the freeze window.
+	Begin metav1.Time `json:"begin"`
+	// End is the end of the freeze window.
+	End metav1.Time `json:"end"`
+	// AllowExpiredForceUpdates specifies whether force updates of truly expired versions are still applied during
+	// the freeze window.
+	// +optional
+	AllowExpiredForceUpdates bool `json:"allowExpiredForceUpdates,omitempty"`
 }
 
 // ShootQuotaControllerConfiguration defines the configuration of the
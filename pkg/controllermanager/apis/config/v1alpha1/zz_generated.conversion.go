@@ -139,6 +139,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceFreezeWindow)(nil), (*config.MaintenanceFreezeWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MaintenanceFreezeWindow_To_config_MaintenanceFreezeWindow(a.(*MaintenanceFreezeWindow), b.(*config.MaintenanceFreezeWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MaintenanceFreezeWindow)(nil), (*MaintenanceFreezeWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MaintenanceFreezeWindow_To_v1alpha1_MaintenanceFreezeWindow(a.(*config.MaintenanceFreezeWindow), b.(*MaintenanceFreezeWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceTimeWindow)(nil), (*config.MaintenanceTimeWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MaintenanceTimeWindow_To_config_MaintenanceTimeWindow(a.(*MaintenanceTimeWindow), b.(*config.MaintenanceTimeWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MaintenanceTimeWindow)(nil), (*MaintenanceTimeWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MaintenanceTimeWindow_To_v1alpha1_MaintenanceTimeWindow(a.(*config.MaintenanceTimeWindow), b.(*MaintenanceTimeWindow), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ManagedSeedSetControllerConfiguration)(nil), (*config.ManagedSeedSetControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ManagedSeedSetControllerConfiguration_To_config_ManagedSeedSetControllerConfiguration(a.(*ManagedSeedSetControllerConfiguration), b.(*config.ManagedSeedSetControllerConfiguration), scope)
 	}); err != nil {
@@ -640,6 +660,52 @@ func Convert_config_ExposureClassControllerConfiguration_To_v1alpha1_ExposureCla
 	return autoConvert_config_ExposureClassControllerConfiguration_To_v1alpha1_ExposureClassControllerConfiguration(in, out, s)
 }
 
+func autoConvert_v1alpha1_MaintenanceFreezeWindow_To_config_MaintenanceFreezeWindow(in *MaintenanceFreezeWindow, out *config.MaintenanceFreezeWindow, s conversion.Scope) error {
+	out.Begin = in.Begin
+	out.End = in.End
+	out.AllowExpiredForceUpdates = in.AllowExpiredForceUpdates
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceFreezeWindow_To_config_MaintenanceFreezeWindow is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceFreezeWindow_To_config_MaintenanceFreezeWindow(in *MaintenanceFreezeWindow, out *config.MaintenanceFreezeWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceFreezeWindow_To_config_MaintenanceFreezeWindow(in, out, s)
+}
+
+func autoConvert_config_MaintenanceFreezeWindow_To_v1alpha1_MaintenanceFreezeWindow(in *config.MaintenanceFreezeWindow, out *MaintenanceFreezeWindow, s conversion.Scope) error {
+	out.Begin = in.Begin
+	out.End = in.End
+	out.AllowExpiredForceUpdates = in.AllowExpiredForceUpdates
+	return nil
+}
+
+// Convert_config_MaintenanceFreezeWindow_To_v1alpha1_MaintenanceFreezeWindow is an autogenerated conversion function.
+func Convert_config_MaintenanceFreezeWindow_To_v1alpha1_MaintenanceFreezeWindow(in *config.MaintenanceFreezeWindow, out *MaintenanceFreezeWindow, s conversion.Scope) error {
+	return autoConvert_config_MaintenanceFreezeWindow_To_v1alpha1_MaintenanceFreezeWindow(in, out, s)
+}
+
+func autoConvert_v1alpha1_MaintenanceTimeWindow_To_config_MaintenanceTimeWindow(in *MaintenanceTimeWindow, out *config.MaintenanceTimeWindow, s conversion.Scope) error {
+	out.Begin = in.Begin
+	out.End = in.End
+	return nil
+}
+
+// Convert_v1alpha1_MaintenanceTimeWindow_To_config_MaintenanceTimeWindow is an autogenerated conversion function.
+func Convert_v1alpha1_MaintenanceTimeWindow_To_config_MaintenanceTimeWindow(in *MaintenanceTimeWindow, out *config.MaintenanceTimeWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MaintenanceTimeWindow_To_config_MaintenanceTimeWindow(in, out, s)
+}
+
+func autoConvert_config_MaintenanceTimeWindow_To_v1alpha1_MaintenanceTimeWindow(in *config.MaintenanceTimeWindow, out *MaintenanceTimeWindow, s conversion.Scope) error {
+	out.Begin = in.Begin
+	out.End = in.End
+	return nil
+}
+
+// Convert_config_MaintenanceTimeWindow_To_v1alpha1_MaintenanceTimeWindow is an autogenerated conversion function.
+func Convert_config_MaintenanceTimeWindow_To_v1alpha1_MaintenanceTimeWindow(in *config.MaintenanceTimeWindow, out *MaintenanceTimeWindow, s conversion.Scope) error {
+	return autoConvert_config_MaintenanceTimeWindow_To_v1alpha1_MaintenanceTimeWindow(in, out, s)
+}
+
 func autoConvert_v1alpha1_ManagedSeedSetControllerConfiguration_To_config_ManagedSeedSetControllerConfiguration(in *ManagedSeedSetControllerConfiguration, out *config.ManagedSeedSetControllerConfiguration, s conversion.Scope) error {
 	out.ConcurrentSyncs = (*int)(unsafe.Pointer(in.ConcurrentSyncs))
 	out.MaxShootRetries = (*int)(unsafe.Pointer(in.MaxShootRetries))
@@ -939,6 +1005,14 @@ func autoConvert_v1alpha1_ShootMaintenanceControllerConfiguration_To_config_Shoo
 	out.ConcurrentSyncs = (*int)(unsafe.Pointer(in.ConcurrentSyncs))
 	out.EnableShootControlPlaneRestarter = (*bool)(unsafe.Pointer(in.EnableShootControlPlaneRestarter))
 	out.EnableShootCoreAddonRestarter = (*bool)(unsafe.Pointer(in.EnableShootCoreAddonRestarter))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.MaxConcurrentPerSeed = (*int32)(unsafe.Pointer(in.MaxConcurrentPerSeed))
+	out.NotificationWebhookURL = (*string)(unsafe.Pointer(in.NotificationWebhookURL))
+	out.MinDisruptiveUpdateInterval = (*v1.Duration)(unsafe.Pointer(in.MinDisruptiveUpdateInterval))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.FreezeWindows = *(*[]config.MaintenanceFreezeWindow)(unsafe.Pointer(&in.FreezeWindows))
+	out.DefaultTimeWindow = (*config.MaintenanceTimeWindow)(unsafe.Pointer(in.DefaultTimeWindow))
+	out.MinTimeWindowDuration = (*v1.Duration)(unsafe.Pointer(in.MinTimeWindowDuration))
 	return nil
 }
 
@@ -951,6 +1025,14 @@ func autoConvert_config_ShootMaintenanceControllerConfiguration_To_v1alpha1_Shoo
 	out.ConcurrentSyncs = (*int)(unsafe.Pointer(in.ConcurrentSyncs))
 	out.EnableShootControlPlaneRestarter = (*bool)(unsafe.Pointer(in.EnableShootControlPlaneRestarter))
 	out.EnableShootCoreAddonRestarter = (*bool)(unsafe.Pointer(in.EnableShootCoreAddonRestarter))
+	out.MaxDuration = (*v1.Duration)(unsafe.Pointer(in.MaxDuration))
+	out.MaxConcurrentPerSeed = (*int32)(unsafe.Pointer(in.MaxConcurrentPerSeed))
+	out.NotificationWebhookURL = (*string)(unsafe.Pointer(in.NotificationWebhookURL))
+	out.MinDisruptiveUpdateInterval = (*v1.Duration)(unsafe.Pointer(in.MinDisruptiveUpdateInterval))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.FreezeWindows = *(*[]MaintenanceFreezeWindow)(unsafe.Pointer(&in.FreezeWindows))
+	out.DefaultTimeWindow = (*MaintenanceTimeWindow)(unsafe.Pointer(in.DefaultTimeWindow))
+	out.MinTimeWindowDuration = (*v1.Duration)(unsafe.Pointer(in.MinTimeWindowDuration))
 	return nil
 }
 
@@ -359,6 +359,40 @@ func (in *ExposureClassControllerConfiguration) DeepCopy() *ExposureClassControl
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceFreezeWindow) DeepCopyInto(out *MaintenanceFreezeWindow) {
+	*out = *in
+	in.Begin.DeepCopyInto(&out.Begin)
+	in.End.DeepCopyInto(&out.End)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceFreezeWindow.
+func (in *MaintenanceFreezeWindow) DeepCopy() *MaintenanceFreezeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceFreezeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceTimeWindow) DeepCopyInto(out *MaintenanceTimeWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceTimeWindow.
+func (in *MaintenanceTimeWindow) DeepCopy() *MaintenanceTimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceTimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedSeedSetControllerConfiguration) DeepCopyInto(out *ManagedSeedSetControllerConfiguration) {
 	*out = *in
@@ -703,6 +737,50 @@ func (in *ShootMaintenanceControllerConfiguration) DeepCopyInto(out *ShootMainte
 		*out = new(bool)
 		**out = **in
 	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrentPerSeed != nil {
+		in, out := &in.MaxConcurrentPerSeed, &out.MaxConcurrentPerSeed
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NotificationWebhookURL != nil {
+		in, out := &in.NotificationWebhookURL, &out.NotificationWebhookURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinDisruptiveUpdateInterval != nil {
+		in, out := &in.MinDisruptiveUpdateInterval, &out.MinDisruptiveUpdateInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FreezeWindows != nil {
+		in, out := &in.FreezeWindows, &out.FreezeWindows
+		*out = make([]MaintenanceFreezeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultTimeWindow != nil {
+		in, out := &in.DefaultTimeWindow, &out.DefaultTimeWindow
+		*out = new(MaintenanceTimeWindow)
+		**out = **in
+	}
+	if in.MinTimeWindowDuration != nil {
+		in, out := &in.MinTimeWindowDuration, &out.MinTimeWindowDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -15,23 +15,31 @@
 package maintenance
 
 import (
+	"context"
+
+	"github.com/go-logr/logr"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/controllerutils/mapper"
 )
 
 // ControllerName is the name of this controller.
 const ControllerName = "shoot-maintenance"
 
 // AddToManager adds Reconciler to the given manager.
-func (r *Reconciler) AddToManager(mgr manager.Manager) error {
+func (r *Reconciler) AddToManager(ctx context.Context, mgr manager.Manager) error {
 	if r.Client == nil {
 		r.Client = mgr.GetClient()
 	}
@@ -41,15 +49,79 @@ func (r *Reconciler) AddToManager(mgr manager.Manager) error {
 	if r.Recorder == nil {
 		r.Recorder = mgr.GetEventRecorderFor(ControllerName + "-controller")
 	}
+	if r.SeedLimiter == nil && r.Config.MaxConcurrentPerSeed != nil {
+		r.SeedLimiter = NewSeedLimiter(int(*r.Config.MaxConcurrentPerSeed))
+	}
+	if r.Notifier == nil && r.Config.NotificationWebhookURL != nil {
+		r.Notifier = NewWebhookNotifier(*r.Config.NotificationWebhookURL)
+	}
+	if r.PolicyDecider == nil {
+		r.PolicyDecider = AllowAllPolicyDecider{}
+	}
 
-	return builder.
+	c, err := builder.
 		ControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&gardencorev1beta1.Shoot{}, builder.WithPredicates(r.ShootPredicate())).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: pointer.IntDeref(r.Config.ConcurrentSyncs, 0),
 		}).
-		Complete(r)
+		Build(r)
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(
+		source.Kind(mgr.GetCache(), &gardencorev1beta1.CloudProfile{}),
+		mapper.EnqueueRequestsFrom(ctx, mgr.GetCache(), mapper.MapFunc(r.MapCloudProfileToShoots), mapper.UpdateWithNew, c.GetLogger()),
+		r.CloudProfilePredicate(),
+	)
+}
+
+// CloudProfilePredicate reacts on CloudProfile changes which are relevant for maintenance, i.e. changes to the
+// version lists (e.g. a version expired or was removed).
+func (r *Reconciler) CloudProfilePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			cloudProfile, ok := e.ObjectNew.(*gardencorev1beta1.CloudProfile)
+			if !ok {
+				return false
+			}
+
+			oldCloudProfile, ok := e.ObjectOld.(*gardencorev1beta1.CloudProfile)
+			if !ok {
+				return false
+			}
+
+			return !apiequality.Semantic.DeepEqual(oldCloudProfile.Spec.Kubernetes, cloudProfile.Spec.Kubernetes) ||
+				!apiequality.Semantic.DeepEqual(oldCloudProfile.Spec.MachineImages, cloudProfile.Spec.MachineImages)
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool { return false },
+	}
+}
+
+// MapCloudProfileToShoots is a mapper.MapFunc for mapping a CloudProfile to all Shoots referencing it, so that
+// expired versions are handled promptly in-window instead of only in the next maintenance window.
+func (r *Reconciler) MapCloudProfileToShoots(ctx context.Context, log logr.Logger, reader client.Reader, obj client.Object) []reconcile.Request {
+	cloudProfile, ok := obj.(*gardencorev1beta1.CloudProfile)
+	if !ok {
+		return nil
+	}
+
+	shootList := &gardencorev1beta1.ShootList{}
+	if err := reader.List(ctx, shootList); err != nil {
+		log.Error(err, "Failed to list Shoots referencing CloudProfile", "cloudProfile", client.ObjectKeyFromObject(cloudProfile))
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, shoot := range shootList.Items {
+		if shoot.Spec.CloudProfileName == cloudProfile.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: shoot.Namespace, Name: shoot.Name}})
+		}
+	}
+	return requests
 }
 
 // ShootPredicate returns the predicates for the core.gardener.cloud/v1beta1.Shoot watch.
@@ -15,13 +15,21 @@
 package maintenance_test
 
 import (
+	"context"
+
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
 	. "github.com/gardener/gardener/pkg/controllermanager/controller/shoot/maintenance"
 )
 
@@ -106,4 +114,84 @@ var _ = Describe("Add", func() {
 			})
 		})
 	})
+
+	Describe("CloudProfilePredicate", func() {
+		var (
+			p            predicate.Predicate
+			cloudProfile *gardencorev1beta1.CloudProfile
+		)
+
+		BeforeEach(func() {
+			p = reconciler.CloudProfilePredicate()
+			cloudProfile = &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					Kubernetes: gardencorev1beta1.KubernetesSettings{
+						Versions: []gardencorev1beta1.ExpirableVersion{{Version: "1.26.0"}},
+					},
+				},
+			}
+		})
+
+		It("should ignore create and delete events", func() {
+			Expect(p.Create(event.CreateEvent{})).To(BeFalse())
+			Expect(p.Delete(event.DeleteEvent{})).To(BeFalse())
+		})
+
+		It("should return false when the version lists did not change", func() {
+			oldCloudProfile := cloudProfile.DeepCopy()
+			Expect(p.Update(event.UpdateEvent{ObjectNew: cloudProfile, ObjectOld: oldCloudProfile})).To(BeFalse())
+		})
+
+		It("should return true when a Kubernetes version was expired", func() {
+			oldCloudProfile := cloudProfile.DeepCopy()
+			cloudProfile.Spec.Kubernetes.Versions[0].ExpirationDate = &metav1.Time{}
+			Expect(p.Update(event.UpdateEvent{ObjectNew: cloudProfile, ObjectOld: oldCloudProfile})).To(BeTrue())
+		})
+
+		It("should return true when a machine image version changed", func() {
+			oldCloudProfile := cloudProfile.DeepCopy()
+			cloudProfile.Spec.MachineImages = []gardencorev1beta1.MachineImage{{Name: "foo-image"}}
+			Expect(p.Update(event.UpdateEvent{ObjectNew: cloudProfile, ObjectOld: oldCloudProfile})).To(BeTrue())
+		})
+	})
+
+	Describe("MapCloudProfileToShoots", func() {
+		var (
+			ctx        = context.TODO()
+			log        = logr.Discard()
+			fakeClient client.Client
+
+			cloudProfile *gardencorev1beta1.CloudProfile
+		)
+
+		BeforeEach(func() {
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.GardenScheme).Build()
+			cloudProfile = &gardencorev1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "profile"}}
+		})
+
+		It("should enqueue all Shoots referencing the CloudProfile", func() {
+			shoot := &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+				Spec:       gardencorev1beta1.ShootSpec{CloudProfileName: "profile"},
+			}
+			otherShoot := &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-shoot", Namespace: "garden-foo"},
+				Spec:       gardencorev1beta1.ShootSpec{CloudProfileName: "other-profile"},
+			}
+			Expect(fakeClient.Create(ctx, shoot)).To(Succeed())
+			Expect(fakeClient.Create(ctx, otherShoot)).To(Succeed())
+
+			Expect(reconciler.MapCloudProfileToShoots(ctx, log, fakeClient, cloudProfile)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "garden-foo", Name: "shoot"}},
+			))
+		})
+
+		It("should return nil when no Shoot references the CloudProfile", func() {
+			Expect(reconciler.MapCloudProfileToShoots(ctx, log, fakeClient, cloudProfile)).To(BeEmpty())
+		})
+
+		It("should return nil for objects which are no CloudProfile", func() {
+			Expect(reconciler.MapCloudProfileToShoots(ctx, log, fakeClient, &gardencorev1beta1.Shoot{})).To(BeNil())
+		})
+	})
 })
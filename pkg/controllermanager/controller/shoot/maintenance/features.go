@@ -0,0 +1,35 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+const (
+	// FeatureCanaryUpdates enables deferring version updates of non-canary worker pools until the canary worker pool
+	// update was rolled out.
+	FeatureCanaryUpdates = "CanaryUpdates"
+	// FeaturePolicyDecisions enables consulting the configured PolicyDecider for every planned version update.
+	FeaturePolicyDecisions = "PolicyDecisions"
+	// FeaturePlanConfigMap enables materializing the computed maintenance plan as a ConfigMap in the Shoot's namespace
+	// so that it can be inspected by read-only tooling.
+	FeaturePlanConfigMap = "PlanConfigMap"
+	// FeatureCRIMigration enables migrating a worker pool from docker to containerd when a machine image update is
+	// only blocked because all suitable higher versions dropped support for docker.
+	FeatureCRIMigration = "CRIMigration"
+)
+
+// featureEnabled returns true if the given maintenance feature gate is enabled in the controller configuration.
+// All gates default to off so that new maintenance sub-behaviors can be disabled centrally if they misbehave.
+func (r *Reconciler) featureEnabled(name string) bool {
+	return r.Config.FeatureGates[name]
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"sync"
+)
+
+// SeedLimiter limits the number of concurrently running maintenance operations per seed so that many Shoots sharing
+// a maintenance time window do not overload a single seed.
+type SeedLimiter struct {
+	lock   sync.Mutex
+	limit  int
+	counts map[string]int
+}
+
+// NewSeedLimiter returns a new SeedLimiter allowing at most <limit> concurrent maintenance operations per seed.
+func NewSeedLimiter(limit int) *SeedLimiter {
+	return &SeedLimiter{
+		limit:  limit,
+		counts: make(map[string]int),
+	}
+}
+
+// TryAcquire reserves a maintenance slot for the given seed. It returns false if the limit for the seed is already
+// reached, in which case the caller must retry later.
+func (l *SeedLimiter) TryAcquire(seedName string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.counts[seedName] >= l.limit {
+		return false
+	}
+
+	l.counts[seedName]++
+	return true
+}
+
+// Release frees a previously acquired maintenance slot for the given seed.
+func (l *SeedLimiter) Release(seedName string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.counts[seedName] <= 1 {
+		delete(l.counts, seedName)
+		return
+	}
+
+	l.counts[seedName]--
+}
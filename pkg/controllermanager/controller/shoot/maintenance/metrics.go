@@ -0,0 +1,107 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	runtimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+const (
+	metricReasonAuto  = "auto"
+	metricReasonForce = "force"
+
+	metricKindKubernetes   = "kubernetes"
+	metricKindMachineImage = "machineimage"
+
+	metricResultSuccess = "success"
+	metricResultFailure = "failure"
+)
+
+// versionUpdates counts the version updates attempted by the maintenance controller, partitioned by the update
+// reason (auto/force), the kind of the updated version (kubernetes/machineimage), and the result (success/failure).
+var versionUpdates = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "gardener_controller_manager",
+		Name:      "shoot_maintenance_version_updates_total",
+		Help:      "Total number of version updates attempted by the shoot maintenance controller.",
+	},
+	[]string{"reason", "kind", "result"},
+)
+
+// nearestVersionExpiry exposes the seconds until the soonest expiration date among the versions configured in a
+// Shoot, so that upcoming force updates can be alerted on fleet-wide.
+var nearestVersionExpiry = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: "gardener_controller_manager",
+		Name:      "shoot_maintenance_nearest_version_expiry_seconds",
+		Help:      "Seconds until the soonest expiration date of a version configured in a Shoot. Negative once a version is expired.",
+	},
+	[]string{"namespace", "name"},
+)
+
+func init() {
+	runtimemetrics.Registry.MustRegister(versionUpdates, nearestVersionExpiry)
+}
+
+// recordNearestVersionExpiryMetric sets the expiry gauge of the given Shoot to the seconds remaining until its soonest
+// version expiry. The gauge is removed when none of the configured versions has an expiration date.
+func recordNearestVersionExpiryMetric(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile, now time.Time) {
+	nearest := nearestExpirationDate(shoot, cloudProfile)
+	if nearest == nil {
+		nearestVersionExpiry.DeleteLabelValues(shoot.Namespace, shoot.Name)
+		return
+	}
+
+	nearestVersionExpiry.WithLabelValues(shoot.Namespace, shoot.Name).Set(nearest.Sub(now).Seconds())
+}
+
+// deleteNearestVersionExpiryMetric removes the expiry gauge of a deleted Shoot.
+func deleteNearestVersionExpiryMetric(namespace, name string) {
+	nearestVersionExpiry.DeleteLabelValues(namespace, name)
+}
+
+// recordVersionUpdateMetrics increments the version update counters for all updates of a single maintenance run.
+func recordVersionUpdateMetrics(kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) {
+	record := func(result updateResult, kind string) {
+		reason := metricReasonAuto
+		if result.isForceful {
+			reason = metricReasonForce
+		}
+
+		outcome := metricResultSuccess
+		if !result.isSuccessful {
+			outcome = metricResultFailure
+		}
+
+		versionUpdates.WithLabelValues(reason, kind, outcome).Inc()
+	}
+
+	if kubernetesControlPlaneUpdate != nil {
+		record(*kubernetesControlPlaneUpdate, metricKindKubernetes)
+	}
+
+	for _, result := range workerToKubernetesUpdate {
+		record(result, metricKindKubernetes)
+	}
+
+	for _, result := range workerToMachineImageUpdate {
+		record(result, metricKindMachineImage)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("#recordVersionUpdateMetrics", func() {
+		It("should increment the counters for a force-update scenario", func() {
+			registry := prometheus.NewPedanticRegistry()
+			Expect(registry.Register(versionUpdates)).To(Succeed())
+
+			var (
+				kubernetesForceSuccess   = testutil.ToFloat64(versionUpdates.WithLabelValues(metricReasonForce, metricKindKubernetes, metricResultSuccess))
+				machineImageForceFailure = testutil.ToFloat64(versionUpdates.WithLabelValues(metricReasonForce, metricKindMachineImage, metricResultFailure))
+				machineImageAutoSuccess  = testutil.ToFloat64(versionUpdates.WithLabelValues(metricReasonAuto, metricKindMachineImage, metricResultSuccess))
+			)
+
+			recordVersionUpdateMetrics(
+				&updateResult{isSuccessful: true, isForceful: true},
+				map[string]updateResult{
+					"cpu-worker": {isSuccessful: true, isForceful: true},
+				},
+				map[string]updateResult{
+					"cpu-worker": {isSuccessful: false, isForceful: true},
+					"gpu-worker": {isSuccessful: true},
+				},
+			)
+
+			Expect(testutil.ToFloat64(versionUpdates.WithLabelValues(metricReasonForce, metricKindKubernetes, metricResultSuccess)) - kubernetesForceSuccess).To(Equal(float64(2)))
+			Expect(testutil.ToFloat64(versionUpdates.WithLabelValues(metricReasonForce, metricKindMachineImage, metricResultFailure)) - machineImageForceFailure).To(Equal(float64(1)))
+			Expect(testutil.ToFloat64(versionUpdates.WithLabelValues(metricReasonAuto, metricKindMachineImage, metricResultSuccess)) - machineImageAutoSuccess).To(Equal(float64(1)))
+		})
+	})
+
+	Describe("#recordNearestVersionExpiryMetric", func() {
+		var (
+			now          time.Time
+			shoot        *gardencorev1beta1.Shoot
+			cloudProfile *gardencorev1beta1.CloudProfile
+		)
+
+		BeforeEach(func() {
+			now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+			shoot = &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "garden-bar"},
+				Spec: gardencorev1beta1.ShootSpec{
+					Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+					Provider: gardencorev1beta1.Provider{
+						Workers: []gardencorev1beta1.Worker{
+							{
+								Name: "cpu-worker",
+								Machine: gardencorev1beta1.Machine{
+									Image: &gardencorev1beta1.ShootMachineImage{
+										Name:    "gardenlinux",
+										Version: pointer.String("1.0.0"),
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			cloudProfile = &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					Kubernetes: gardencorev1beta1.KubernetesSettings{
+						Versions: []gardencorev1beta1.ExpirableVersion{
+							{Version: "1.26.0", ExpirationDate: &metav1.Time{Time: now.Add(48 * time.Hour)}},
+						},
+					},
+					MachineImages: []gardencorev1beta1.MachineImage{
+						{
+							Name: "gardenlinux",
+							Versions: []gardencorev1beta1.MachineImageVersion{
+								{ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.0.0", ExpirationDate: &metav1.Time{Time: now.Add(24 * time.Hour)}}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			deleteNearestVersionExpiryMetric(shoot.Namespace, shoot.Name)
+		})
+
+		It("should set the gauge to the seconds until the nearest expiration date", func() {
+			recordNearestVersionExpiryMetric(shoot, cloudProfile, now)
+
+			Expect(testutil.ToFloat64(nearestVersionExpiry.WithLabelValues(shoot.Namespace, shoot.Name))).To(Equal((24 * time.Hour).Seconds()))
+		})
+
+		It("should set a negative gauge value when the nearest version is already expired", func() {
+			cloudProfile.Spec.MachineImages[0].Versions[0].ExpirationDate = &metav1.Time{Time: now.Add(-time.Hour)}
+
+			recordNearestVersionExpiryMetric(shoot, cloudProfile, now)
+
+			Expect(testutil.ToFloat64(nearestVersionExpiry.WithLabelValues(shoot.Namespace, shoot.Name))).To(Equal((-time.Hour).Seconds()))
+		})
+
+		It("should remove the gauge when no configured version has an expiration date", func() {
+			recordNearestVersionExpiryMetric(shoot, cloudProfile, now)
+
+			cloudProfile.Spec.Kubernetes.Versions[0].ExpirationDate = nil
+			cloudProfile.Spec.MachineImages[0].Versions[0].ExpirationDate = nil
+			recordNearestVersionExpiryMetric(shoot, cloudProfile, now)
+
+			Expect(testutil.CollectAndCount(nearestVersionExpiry)).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,96 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// notifyAttempts is the maximum number of attempts for delivering a force-update notification.
+	notifyAttempts = 3
+	// notifyTimeout is the timeout for a single request to the notification webhook.
+	notifyTimeout = 10 * time.Second
+)
+
+// ForceUpdateNotification is the JSON payload which is POSTed to the configured notification webhook whenever a
+// maintenance operation forcefully updated a version because it expired.
+type ForceUpdateNotification struct {
+	// Shoot is the name of the maintained Shoot.
+	Shoot string `json:"shoot"`
+	// Namespace is the namespace of the maintained Shoot.
+	Namespace string `json:"namespace"`
+	// Target describes what was updated, e.g. the control plane Kubernetes version or a worker pool machine image.
+	Target string `json:"target"`
+	// FromVersion is the version before the update.
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is the version after the update.
+	ToVersion string `json:"toVersion"`
+	// Reason is the reason why the update was performed.
+	Reason string `json:"reason"`
+}
+
+// WebhookNotifier delivers force-update notifications to a configured webhook URL.
+type WebhookNotifier struct {
+	// URL is the URL of the notification webhook.
+	URL string
+
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a new WebhookNotifier for the given webhook URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		client: &http.Client{Timeout: notifyTimeout},
+	}
+}
+
+// Notify POSTs the given notification to the configured webhook. Delivery is retried a bounded number of times before
+// an error is returned.
+func (n *WebhookNotifier) Notify(ctx context.Context, notification ForceUpdateNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	var lastErr error
+	for i := 0; i < notifyAttempts; i++ {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/json")
+
+		response, err := n.client.Do(request)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_ = response.Body.Close()
+
+		if response.StatusCode >= 200 && response.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("webhook returned status code %d", response.StatusCode)
+	}
+
+	return fmt.Errorf("failed to deliver notification after %d attempts: %w", notifyAttempts, lastErr)
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/controllerutils"
+)
+
+const (
+	// planConfigMapNameSuffix is the suffix appended to the Shoot name to build the name of the maintenance plan
+	// ConfigMap.
+	planConfigMapNameSuffix = ".maintenance-plan"
+	// planConfigMapDataKey is the key in the maintenance plan ConfigMap under which the plan document is stored.
+	planConfigMapDataKey = "plan.yaml"
+)
+
+// maintenancePlan is the document written to the maintenance plan ConfigMap.
+type maintenancePlan struct {
+	// Kubernetes lists the planned Kubernetes version changes of the control plane and the worker pools.
+	Kubernetes []planEntry `json:"kubernetes,omitempty"`
+	// MachineImages lists the planned machine image version changes of the worker pools.
+	MachineImages []planEntry `json:"machineImages,omitempty"`
+}
+
+// planEntry describes a single planned version change of a maintenance run.
+type planEntry struct {
+	// Target names the part of the Shoot the change applies to.
+	Target string `json:"target"`
+	// FromVersion is the version before the change.
+	FromVersion string `json:"fromVersion,omitempty"`
+	// ToVersion is the version the target is updated to.
+	ToVersion string `json:"toVersion,omitempty"`
+	// Reason states why the change is performed.
+	Reason string `json:"reason,omitempty"`
+}
+
+// updatePlanConfigMap materializes the computed maintenance plan as a ConfigMap in the Shoot's namespace so that it can
+// be inspected by read-only tooling (e.g. GitOps audits). The ConfigMap is refreshed on every maintenance run.
+func (r *Reconciler) updatePlanConfigMap(ctx context.Context, shoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) error {
+	plan := maintenancePlan{}
+
+	if kubernetesControlPlaneUpdate != nil {
+		plan.Kubernetes = append(plan.Kubernetes, planEntryForResult("Control Plane", *kubernetesControlPlaneUpdate))
+	}
+	for _, pool := range sortedKeys(workerToKubernetesUpdate) {
+		plan.Kubernetes = append(plan.Kubernetes, planEntryForResult(fmt.Sprintf("Worker pool %q", pool), workerToKubernetesUpdate[pool]))
+	}
+	for _, pool := range sortedKeys(workerToMachineImageUpdate) {
+		plan.MachineImages = append(plan.MachineImages, planEntryForResult(fmt.Sprintf("Worker pool %q", pool), workerToMachineImageUpdate[pool]))
+	}
+
+	planYAML, err := yaml.Marshal(&plan)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: shoot.Name + planConfigMapNameSuffix, Namespace: shoot.Namespace}}
+	_, err = controllerutils.GetAndCreateOrMergePatch(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{planConfigMapDataKey: string(planYAML)}
+		return nil
+	})
+	return err
+}
+
+func planEntryForResult(target string, result updateResult) planEntry {
+	return planEntry{
+		Target:      target,
+		FromVersion: result.fromVersion,
+		ToVersion:   result.toVersion,
+		Reason:      result.reason,
+	}
+}
+
+func sortedKeys(results map[string]updateResult) []string {
+	keys := make([]string, 0, len(results))
+	for key := range results {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// PolicyDecision is the decision of a PolicyDecider about a planned version update.
+type PolicyDecision struct {
+	// Allowed specifies whether the update may be applied.
+	Allowed bool
+	// Reason optionally explains why the update was denied.
+	Reason string
+	// OverrideVersion optionally replaces the target version of an allowed update.
+	OverrideVersion *string
+}
+
+// PolicyDecider decides whether a version update determined during maintenance may be applied. It allows integrating
+// external policy systems into the maintenance flow without forking the controller.
+type PolicyDecider interface {
+	// Decide is called for every planned version update of the given target (e.g. the control plane Kubernetes
+	// version or a worker pool machine image) before it is applied.
+	Decide(ctx context.Context, shoot *gardencorev1beta1.Shoot, target, fromVersion, toVersion string) PolicyDecision
+}
+
+// AllowAllPolicyDecider is a PolicyDecider allowing every update. It is used when no custom decider is injected.
+type AllowAllPolicyDecider struct{}
+
+// Decide allows the update.
+func (AllowAllPolicyDecider) Decide(_ context.Context, _ *gardencorev1beta1.Shoot, _, _, _ string) PolicyDecision {
+	return PolicyDecision{Allowed: true}
+}
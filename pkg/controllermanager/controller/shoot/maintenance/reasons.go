@@ -0,0 +1,68 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// MaintenanceReason explains why a version update was performed during maintenance. Its String representation
+// renders the exact phrases historically assembled ad-hoc in the maintenance descriptions, so that tests and
+// consumers no longer need to rely on free-form text.
+type MaintenanceReason int
+
+const (
+	// ReasonVersionMissingInCloudProfile indicates that the current version does not exist in the CloudProfile anymore.
+	ReasonVersionMissingInCloudProfile MaintenanceReason = iota
+	// ReasonKubernetesVersionExpired indicates that the current Kubernetes version expired and must be force-updated.
+	ReasonKubernetesVersionExpired
+	// ReasonKubernetesAutoUpdate indicates that the automatic update of the Kubernetes version is configured.
+	ReasonKubernetesAutoUpdate
+	// ReasonMachineImageVersionExpired indicates that the current machine image version expired and must be force-updated.
+	ReasonMachineImageVersionExpired
+	// ReasonMachineImageAutoUpdate indicates that the automatic update of the machine image version is configured.
+	ReasonMachineImageAutoUpdate
+	// ReasonMachineImagePatchCatchUp indicates that the expired machine image version was first caught up to the
+	// latest patch version of its minor before the actual force update across the minor/major boundary.
+	ReasonMachineImagePatchCatchUp
+)
+
+// String renders the legacy description phrase of this reason.
+func (r MaintenanceReason) String() string {
+	switch r {
+	case ReasonVersionMissingInCloudProfile:
+		return "Version does not exist in CloudProfile"
+	case ReasonKubernetesVersionExpired:
+		return "Kubernetes version expired - force update required"
+	case ReasonKubernetesAutoUpdate:
+		return "Automatic update of Kubernetes version configured"
+	case ReasonMachineImageVersionExpired:
+		return "Machine image version expired - force update required"
+	case ReasonMachineImageAutoUpdate:
+		return "Automatic update of the machine image version is configured"
+	case ReasonMachineImagePatchCatchUp:
+		return "Machine image version expired - patch catch-up before force update"
+	default:
+		return "Unknown maintenance reason"
+	}
+}
+
+// WithUpdateStrategy appends the machine image update strategy to the rendered reason, matching the legacy
+// phrasing of the machine image maintenance descriptions.
+func (r MaintenanceReason) WithUpdateStrategy(updateStrategy gardencorev1beta1.MachineImageUpdateStrategy) string {
+	return fmt.Sprintf("%s (image update strategy: %s)", r, updateStrategy)
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+var _ = Describe("MaintenanceReason", func() {
+	DescribeTable("#String should render the legacy description phrases",
+		func(reason MaintenanceReason, expected string) {
+			Expect(reason.String()).To(Equal(expected))
+		},
+
+		Entry("version missing in CloudProfile", ReasonVersionMissingInCloudProfile, "Version does not exist in CloudProfile"),
+		Entry("kubernetes version expired", ReasonKubernetesVersionExpired, "Kubernetes version expired - force update required"),
+		Entry("kubernetes auto update", ReasonKubernetesAutoUpdate, "Automatic update of Kubernetes version configured"),
+		Entry("machine image version expired", ReasonMachineImageVersionExpired, "Machine image version expired - force update required"),
+		Entry("machine image auto update", ReasonMachineImageAutoUpdate, "Automatic update of the machine image version is configured"),
+		Entry("machine image patch catch-up", ReasonMachineImagePatchCatchUp, "Machine image version expired - patch catch-up before force update"),
+	)
+
+	Describe("#WithUpdateStrategy", func() {
+		It("should append the machine image update strategy", func() {
+			Expect(ReasonMachineImageVersionExpired.WithUpdateStrategy(gardencorev1beta1.UpdateStrategyMajor)).
+				To(Equal("Machine image version expired - force update required (image update strategy: major)"))
+			Expect(ReasonMachineImageAutoUpdate.WithUpdateStrategy(gardencorev1beta1.UpdateStrategyPatch)).
+				To(Equal("Automatic update of the machine image version is configured (image update strategy: patch)"))
+		})
+	})
+})
@@ -16,7 +16,9 @@ package maintenance
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"slices"
 	"strconv"
 	"strings"
@@ -24,9 +26,11 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
@@ -41,15 +45,51 @@ import (
 	"github.com/gardener/gardener/pkg/controllerutils"
 	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 	kubernetesutils "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/timewindow"
+	featuresvalidation "github.com/gardener/gardener/pkg/utils/validation/features"
 	versionutils "github.com/gardener/gardener/pkg/utils/version"
 )
 
+const (
+	// AnnotationNextMaintenanceWindowStart is an annotation on a Shoot announcing the start of the next scheduled
+	// maintenance (including the random jitter within the configured time window).
+	AnnotationNextMaintenanceWindowStart = "maintenance.shoot.gardener.cloud/next-window-start"
+	// AnnotationNextMaintenanceWindowEnd is an annotation on a Shoot announcing the end of the time window in which
+	// the next scheduled maintenance will run.
+	AnnotationNextMaintenanceWindowEnd = "maintenance.shoot.gardener.cloud/next-window-end"
+	// AnnotationAutoUpdateMachineImageNames is an annotation on a Shoot restricting the machine image auto-update to
+	// the contained comma-separated list of image names. Images not in the list are only updated forcefully when their
+	// version expired. When the annotation is not set, all machine images are auto-updated.
+	AnnotationAutoUpdateMachineImageNames = "maintenance.shoot.gardener.cloud/auto-update-machine-image-names"
+
+	// AnnotationMaintenancePolicy is an annotation on a Shoot referencing a cluster-scoped MaintenancePolicy by name.
+	// Maintenance settings not configured on the Shoot itself are inherited from the referenced policy.
+	AnnotationMaintenancePolicy = "maintenance.shoot.gardener.cloud/policy"
+
+	// ReasonNoHigherVersionAvailable is the uniform failure reason reported in the Shoot's maintenance status when an
+	// expired version cannot be updated forcefully because no higher version exists within the bounds of the update
+	// strategy. It is used for both the Kubernetes and the machine image flow.
+	ReasonNoHigherVersionAvailable = "NoHigherVersionAvailable"
+	// ReasonWouldDowngrade is the failure reason reported in the Shoot's maintenance status when the determined target
+	// version is lower than the current version, e.g. because of a CloudProfile misconfiguration. Such updates are
+	// refused because maintenance must never downgrade a version.
+	ReasonWouldDowngrade = "WouldDowngrade"
+)
+
+// ErrNoHigherVersionAvailable is a sentinel error indicating that an expired version cannot be updated forcefully
+// because no higher version exists within the bounds of the update strategy.
+var ErrNoHigherVersionAvailable = errors.New("no higher version available")
+
 // Reconciler reconciles Shoots and maintains them by updating versions or triggering operations.
 type Reconciler struct {
-	Client   client.Client
-	Config   config.ShootMaintenanceControllerConfiguration
-	Clock    clock.Clock
-	Recorder record.EventRecorder
+	Client      client.Client
+	Config      config.ShootMaintenanceControllerConfiguration
+	Clock       clock.Clock
+	Recorder    record.EventRecorder
+	SeedLimiter *SeedLimiter
+	Notifier    *WebhookNotifier
+	// PolicyDecider is consulted for every planned version update. It defaults to an allow-all implementation.
+	PolicyDecider PolicyDecider
 }
 
 // Reconcile reconciles Shoots and maintains them by updating versions or triggering operations.
@@ -63,6 +103,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err := r.Client.Get(ctx, request.NamespacedName, shoot); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.V(1).Info("Object is gone, stop reconciling")
+			deleteNearestVersionExpiryMetric(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, fmt.Errorf("error retrieving object from store: %w", err)
@@ -73,14 +114,45 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, nil
 	}
 
-	requeueAfter, nextMaintenance := requeueAfterDuration(shoot)
+	policy, err := r.resolveMaintenancePolicy(ctx, shoot)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
-	if !mustMaintainNow(shoot, r.Clock) {
+	// When the Shoot does not declare a maintenance time window, the window of a referenced MaintenancePolicy or the
+	// configured default window (if any) is applied for all window computations.
+	shootWithWindow := r.shootWithEffectiveTimeWindow(applyMaintenancePolicy(shoot, policy))
+
+	requeueAfter, nextMaintenance := requeueAfterDuration(shootWithWindow, r.Clock)
+
+	if err := r.updateNextWindowAnnotations(ctx, shootWithWindow, nextMaintenance); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update next maintenance window annotations: %w", err)
+	}
+
+	if err := r.failLongRunningMaintenance(ctx, log, shoot); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The expiry gauge is best-effort: a missing or unreadable CloudProfile is reported when the maintenance itself runs.
+	cloudProfileForMetrics := &gardencorev1beta1.CloudProfile{}
+	if err := r.Client.Get(ctx, kubernetesutils.Key(shoot.Spec.CloudProfileName), cloudProfileForMetrics); err == nil {
+		recordNearestVersionExpiryMetric(shoot, cloudProfileForMetrics, r.Clock.Now())
+	}
+
+	if !mustMaintainNow(shootWithWindow, r.Clock) {
 		log.V(1).Info("Skipping Shoot because it doesn't need to be maintained now")
 		log.V(1).Info("Scheduled next maintenance for Shoot", "duration", requeueAfter.Round(time.Minute), "nextMaintenance", nextMaintenance.Round(time.Minute))
 		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
+	if r.SeedLimiter != nil && shoot.Spec.SeedName != nil {
+		if !r.SeedLimiter.TryAcquire(*shoot.Spec.SeedName) {
+			log.V(1).Info("Delaying maintenance because the maximum number of concurrent maintenance operations for the seed is already running", "seedName", *shoot.Spec.SeedName)
+			return reconcile.Result{RequeueAfter: time.Minute}, nil
+		}
+		defer r.SeedLimiter.Release(*shoot.Spec.SeedName)
+	}
+
 	if err := r.reconcile(ctx, log, shoot); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -89,17 +161,135 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func requeueAfterDuration(shoot *gardencorev1beta1.Shoot) (time.Duration, time.Time) {
+// resolveMaintenancePolicy fetches the MaintenancePolicy referenced via the policy annotation. It returns nil when
+// the Shoot does not reference a policy.
+func (r *Reconciler) resolveMaintenancePolicy(ctx context.Context, shoot *gardencorev1beta1.Shoot) (*gardencorev1beta1.MaintenancePolicy, error) {
+	policyName, ok := shoot.Annotations[AnnotationMaintenancePolicy]
+	if !ok || policyName == "" {
+		return nil, nil
+	}
+
+	policy := &gardencorev1beta1.MaintenancePolicy{}
+	if err := r.Client.Get(ctx, kubernetesutils.Key(policyName), policy); err != nil {
+		return nil, fmt.Errorf("unable to fetch MaintenancePolicy %q referenced by the Shoot: %w", policyName, err)
+	}
+
+	return policy, nil
+}
+
+// applyMaintenancePolicy returns the given Shoot, or a copy whose unset maintenance settings are filled with the
+// settings of the given policy. Settings configured on the Shoot itself always take precedence.
+func applyMaintenancePolicy(shoot *gardencorev1beta1.Shoot, policy *gardencorev1beta1.MaintenancePolicy) *gardencorev1beta1.Shoot {
+	if policy == nil {
+		return shoot
+	}
+
+	shootWithPolicy := shoot.DeepCopy()
+	if shootWithPolicy.Spec.Maintenance == nil {
+		shootWithPolicy.Spec.Maintenance = &gardencorev1beta1.Maintenance{}
+	}
+	if shootWithPolicy.Spec.Maintenance.TimeWindow == nil && policy.Spec.TimeWindow != nil {
+		shootWithPolicy.Spec.Maintenance.TimeWindow = policy.Spec.TimeWindow.DeepCopy()
+	}
+	if shootWithPolicy.Spec.Maintenance.AutoUpdate == nil && policy.Spec.AutoUpdate != nil {
+		shootWithPolicy.Spec.Maintenance.AutoUpdate = policy.Spec.AutoUpdate.DeepCopy()
+	}
+	return shootWithPolicy
+}
+
+// usesDefaultTimeWindow returns whether the controller's configured default maintenance time window applies to the
+// given Shoot, i.e. whether a default window is configured and the Shoot does not declare an own one.
+func (r *Reconciler) usesDefaultTimeWindow(shoot *gardencorev1beta1.Shoot) bool {
+	return r.Config.DefaultTimeWindow != nil && (shoot.Spec.Maintenance == nil || shoot.Spec.Maintenance.TimeWindow == nil)
+}
+
+// shootWithEffectiveTimeWindow returns the given Shoot, or a copy with the controller's default maintenance time
+// window filled in when the Shoot does not declare an own one. The copy is only used for window computations, the
+// persisted Shoot is never modified.
+func (r *Reconciler) shootWithEffectiveTimeWindow(shoot *gardencorev1beta1.Shoot) *gardencorev1beta1.Shoot {
+	if !r.usesDefaultTimeWindow(shoot) {
+		return shoot
+	}
+
+	timeWindow := &gardencorev1beta1.MaintenanceTimeWindow{
+		Begin: r.Config.DefaultTimeWindow.Begin,
+		End:   r.Config.DefaultTimeWindow.End,
+	}
+
+	// The window begin is jittered deterministically per Shoot so that the potentially many Shoots relying on the
+	// default window do not all start their maintenance at the same time.
+	if window, err := timewindow.ParseMaintenanceTimeWindow(timeWindow.Begin, timeWindow.End); err == nil {
+		if windowInMinutes := uint32(window.Duration().Minutes()); windowInMinutes > 0 {
+			jitterMinutes := int(crc32.ChecksumIEEE([]byte(shoot.Namespace+"/"+shoot.Name)) % windowInMinutes)
+			timeWindow.Begin = window.Begin().Add(0, jitterMinutes, 0).Formatted()
+		}
+	}
+
+	shootWithWindow := shoot.DeepCopy()
+	if shootWithWindow.Spec.Maintenance == nil {
+		shootWithWindow.Spec.Maintenance = &gardencorev1beta1.Maintenance{}
+	}
+	shootWithWindow.Spec.Maintenance.TimeWindow = timeWindow
+	return shootWithWindow
+}
+
+// requeueAfterDuration computes the exact duration until the next start of the shoot's effective maintenance time
+// window so that the reconciler is requeued precisely at window start instead of polling.
+func requeueAfterDuration(shoot *gardencorev1beta1.Shoot, clock clock.Clock) (time.Duration, time.Time) {
 	var (
-		now             = time.Now()
+		now             = clock.Now()
 		window          = gardenerutils.EffectiveShootMaintenanceTimeWindow(shoot)
-		duration        = window.RandomDurationUntilNext(now, false)
-		nextMaintenance = time.Now().UTC().Add(duration)
+		duration        = window.DurationUntilNext(now)
+		nextMaintenance = now.UTC().Add(duration)
 	)
 
 	return duration, nextMaintenance
 }
 
+// updateNextWindowAnnotations announces the next scheduled maintenance of the Shoot via annotations so that users can
+// easily see when the next maintenance window will run. The annotations are updated whenever the computed values
+// change, e.g. because the maintenance time window configuration was edited.
+func (r *Reconciler) updateNextWindowAnnotations(ctx context.Context, shoot *gardencorev1beta1.Shoot, nextMaintenance time.Time) error {
+	var (
+		window = gardenerutils.EffectiveShootMaintenanceTimeWindow(shoot)
+		start  = nextMaintenance.UTC()
+		end    = time.Date(start.Year(), start.Month(), start.Day(), window.End().Hour(), window.End().Minute(), window.End().Second(), 0, time.UTC)
+	)
+
+	if end.Before(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	if shoot.Annotations[AnnotationNextMaintenanceWindowStart] == start.Format(time.RFC3339) &&
+		shoot.Annotations[AnnotationNextMaintenanceWindowEnd] == end.Format(time.RFC3339) {
+		return nil
+	}
+
+	patch := client.MergeFrom(shoot.DeepCopy())
+	metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, AnnotationNextMaintenanceWindowStart, start.Format(time.RFC3339))
+	metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, AnnotationNextMaintenanceWindowEnd, end.Format(time.RFC3339))
+	return r.Client.Patch(ctx, shoot, patch)
+}
+
+// failLongRunningMaintenance marks a maintenance operation which stays in 'Processing' state for longer than the
+// configured maximum duration as failed, so that it is retried in the next maintenance window.
+func (r *Reconciler) failLongRunningMaintenance(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot) error {
+	if r.Config.MaxDuration == nil || shoot.Status.LastMaintenance == nil || shoot.Status.LastMaintenance.State != gardencorev1beta1.LastOperationStateProcessing {
+		return nil
+	}
+
+	if r.Clock.Now().Sub(shoot.Status.LastMaintenance.TriggeredTime.Time) <= r.Config.MaxDuration.Duration {
+		return nil
+	}
+
+	log.Info("Marking long-running maintenance as failed", "triggeredTime", shoot.Status.LastMaintenance.TriggeredTime, "maxDuration", r.Config.MaxDuration.Duration)
+
+	patch := client.MergeFrom(shoot.DeepCopy())
+	shoot.Status.LastMaintenance.State = gardencorev1beta1.LastOperationStateFailed
+	shoot.Status.LastMaintenance.FailureReason = pointer.String(fmt.Sprintf("Maintenance did not complete within the configured maximum duration of %s", r.Config.MaxDuration.Duration))
+	return r.Client.Status().Patch(ctx, shoot, patch)
+}
+
 // updateResult represents the result of a Kubernetes or Machine image maintenance operation
 // Such maintenance operations can fail if a version must be updated, but the GCM cannot find a suitable version to update to.
 // Note: the updates might still be rejected by APIServer validation.
@@ -107,41 +297,127 @@ type updateResult struct {
 	description  string
 	reason       string
 	isSuccessful bool
+	// isForceful indicates that the update was forced because the previous version expired.
+	isForceful  bool
+	fromVersion string
+	toVersion   string
 }
 
 func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot) error {
 	log.Info("Maintaining Shoot")
 
+	// Refuse to maintain a Shoot with an invalid or too short maintenance time window and record the reason, instead
+	// of running updates in a window the maintenance cannot finish in.
+	if maintenance := shoot.Spec.Maintenance; maintenance != nil && maintenance.TimeWindow != nil {
+		var minDuration time.Duration
+		if r.Config.MinTimeWindowDuration != nil {
+			minDuration = r.Config.MinTimeWindowDuration.Duration
+		}
+
+		if err := timewindow.ValidateMaintenanceTimeWindow(maintenance.TimeWindow.Begin, maintenance.TimeWindow.End, minDuration); err != nil {
+			log.Info("Refusing to maintain Shoot because its maintenance time window is invalid", "reason", err.Error())
+			patch := client.MergeFrom(shoot.DeepCopy())
+			shoot.Status.LastMaintenance = &gardencorev1beta1.LastMaintenance{
+				Description:   "Maintenance failed",
+				TriggeredTime: metav1.Time{Time: r.Clock.Now()},
+				State:         gardencorev1beta1.LastOperationStateFailed,
+				FailureReason: pointer.String(err.Error()),
+			}
+			return r.Client.Status().Patch(ctx, shoot, patch)
+		}
+	}
+
 	var (
 		maintainedShoot = shoot.DeepCopy()
 		// for maintenance operations unrelated to machine images and Kubernetes versions
 		operations []string
-		err        error
+		// reasons for updates which were intentionally skipped, reported via dedicated events
+		skippedUpdates []string
+		// per-target timing information reported in the maintenance status
+		changes []gardencorev1beta1.MaintenanceChange
+		err     error
 	)
 
 	workerToKubernetesUpdate := make(map[string]updateResult)
 	workerToMachineImageUpdate := make(map[string]updateResult)
 
+	if hasForcedMaintainNowAnnotation(shoot) {
+		operations = append(operations, fmt.Sprintf("Maintenance was forced outside of the maintenance time window via the %q annotation", v1beta1constants.GardenerMaintenanceOperation+"="+v1beta1constants.ShootOperationMaintainNow))
+	}
+
+	scope := maintenanceScopeForShoot(shoot)
+	if scope != scopeFull {
+		operations = append(operations, fmt.Sprintf("Maintenance was restricted to the %s via the %q annotation", scope, v1beta1constants.GardenerMaintenanceOperation+"="+shoot.Annotations[v1beta1constants.GardenerMaintenanceOperation]))
+	}
+
+	if r.usesDefaultTimeWindow(shoot) {
+		operations = append(operations, fmt.Sprintf("Maintenance was scheduled by the default maintenance time window (%s-%s) of the controller because the Shoot does not declare an own one", r.Config.DefaultTimeWindow.Begin, r.Config.DefaultTimeWindow.End))
+	}
+
+	policy, err := r.resolveMaintenancePolicy(ctx, shoot)
+	if err != nil {
+		return err
+	}
+	if policy != nil {
+		maintainedShoot.Spec.Maintenance = applyMaintenancePolicy(shoot, policy).Spec.Maintenance
+		operations = append(operations, fmt.Sprintf("Maintenance settings not configured on the Shoot were inherited from MaintenancePolicy %q", policy.Name))
+	}
+
 	cloudProfile := &gardencorev1beta1.CloudProfile{}
 	if err = r.Client.Get(ctx, kubernetesutils.Key(shoot.Spec.CloudProfileName), cloudProfile); err != nil {
+		// report a missing CloudProfile via the maintenance status instead of failing opaquely, the returned error
+		// still makes the maintenance being retried with backoff
+		if apierrors.IsNotFound(err) {
+			patch := client.MergeFrom(shoot.DeepCopy())
+			shoot.Status.LastMaintenance = &gardencorev1beta1.LastMaintenance{
+				Description:   "Maintenance failed",
+				TriggeredTime: metav1.Time{Time: r.Clock.Now()},
+				State:         gardencorev1beta1.LastOperationStateFailed,
+				FailureReason: pointer.String(fmt.Sprintf("CloudProfile %q referenced by the Shoot does not exist", shoot.Spec.CloudProfileName)),
+			}
+			if patchErr := r.Client.Status().Patch(ctx, shoot, patch); patchErr != nil {
+				return patchErr
+			}
+		}
 		return err
 	}
 
-	if !v1beta1helper.IsWorkerless(shoot) {
-		workerToMachineImageUpdate, err = maintainMachineImages(log, maintainedShoot, cloudProfile)
+	if !v1beta1helper.IsWorkerless(shoot) && scope.includesWorkers() {
+		change := r.startChange("Machine images")
+		workerToMachineImageUpdate, err = maintainMachineImages(log, maintainedShoot, cloudProfile, r.featureEnabled(FeatureCRIMigration))
+		changes = append(changes, r.finishChange(change))
 		if err != nil {
 			// continue execution to allow the kubernetes version update
 			log.Error(err, "Failed to maintain Shoot machine images")
+		} else {
+			skippedUpdates = append(skippedUpdates, machineImageUpdateSkipReasons(maintainedShoot, workerToMachineImageUpdate)...)
+		}
+
+		if imageNamesForAutoUpdate := machineImageNamesForAutoUpdate(shoot); imageNamesForAutoUpdate != nil && pointer.BoolDeref(shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion, false) {
+			for _, worker := range shoot.Spec.Provider.Workers {
+				if worker.Machine.Image != nil && !imageNamesForAutoUpdate.Has(worker.Machine.Image.Name) {
+					operations = append(operations, fmt.Sprintf("Machine image %q of worker pool %q is excluded from auto-update", worker.Machine.Image.Name, worker.Name))
+				}
+			}
 		}
 	}
 
-	kubernetesControlPlaneUpdate, err := maintainKubernetesVersion(log, maintainedShoot.Spec.Kubernetes.Version, maintainedShoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile, func(v string) error {
-		maintainedShoot.Spec.Kubernetes.Version = v
-		return nil
-	})
-	if err != nil {
-		// continue execution to allow the machine image version update and Kubernetes updates to worker pools
-		log.Error(err, "Failed to maintain Shoot kubernetes version")
+	var kubernetesControlPlaneUpdate *updateResult
+	if scope.includesControlPlane() {
+		change := r.startChange("Control Plane")
+		kubernetesControlPlaneUpdate, err = maintainKubernetesVersion(log, maintainedShoot.Spec.Kubernetes.Version, maintainedShoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile, func(v string) error {
+			maintainedShoot.Spec.Kubernetes.Version = v
+			return nil
+		})
+		changes = append(changes, r.finishChange(change))
+		if err != nil {
+			// continue execution to allow the machine image version update and Kubernetes updates to worker pools
+			log.Error(err, "Failed to maintain Shoot kubernetes version")
+		} else if kubernetesControlPlaneUpdate == nil {
+			if reason := kubernetesVersionUpdateSkipReason(maintainedShoot.Spec.Kubernetes.Version, maintainedShoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile); reason != "" {
+				skippedUpdates = append(skippedUpdates, fmt.Sprintf("Control Plane: %s", reason))
+			}
+		}
 	}
 
 	oldShootKubernetesVersion, err := semver.NewVersion(shoot.Spec.Kubernetes.Version)
@@ -184,13 +460,37 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 		operations = append(operations, reasonsForIncreasingMaxWorkers...)
 	}
 
+	// Rewrite feature gates which were renamed or removed in the new Kubernetes version so that the updated Shoot is
+	// not rejected by the feature gate validation.
+	operations = append(operations, migrateFeatureGates(maintainedShoot, oldShootKubernetesVersion, shootKubernetesVersion)...)
+
 	// Now it's time to update worker pool kubernetes version if specified
 	for i, pool := range maintainedShoot.Spec.Provider.Workers {
-		if pool.Kubernetes == nil || pool.Kubernetes.Version == nil {
+		if !scope.includesWorkers() || pool.Kubernetes == nil || pool.Kubernetes.Version == nil {
 			continue
 		}
 
 		workerLog := log.WithValues("worker", pool.Name)
+
+		poolKubernetesVersion, err := semver.NewVersion(*pool.Kubernetes.Version)
+		if err != nil {
+			return err
+		}
+
+		// Refuse to maintain worker pools whose version is higher than the (possibly just maintained) control plane
+		// version. Updating them would only increase the version skew, and clamping the target to the control plane
+		// version would mean a downgrade. The control plane is maintained first, so the skew resolves once it caught up.
+		if poolKubernetesVersion.GreaterThan(shootKubernetesVersion) {
+			workerLog.Info("Refusing to maintain Kubernetes version for worker pool because it is higher than the control plane version", "version", *pool.Kubernetes.Version, "controlPlaneVersion", shootKubernetesVersion.String())
+			workerToKubernetesUpdate[pool.Name] = updateResult{
+				description:  fmt.Sprintf("Refused to update the Kubernetes version of worker pool %q: version %q is higher than the control plane version %q - the control plane must be updated first", pool.Name, *pool.Kubernetes.Version, shootKubernetesVersion.String()),
+				reason:       "Worker pool Kubernetes version is higher than the control plane version",
+				isSuccessful: false,
+			}
+			continue
+		}
+
+		change := r.startChange(fmt.Sprintf("Worker pool %q", pool.Name))
 		workerKubernetesUpdate, err := maintainKubernetesVersion(workerLog, *pool.Kubernetes.Version, maintainedShoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile, func(v string) error {
 			workerPoolSemver, err := semver.NewVersion(v)
 			if err != nil {
@@ -207,6 +507,10 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 		if err != nil {
 			// continue execution to allow other maintenance activities to continue
 			workerLog.Error(err, "Could not maintain Kubernetes version for worker pool")
+		} else if workerKubernetesUpdate == nil {
+			if reason := kubernetesVersionUpdateSkipReason(*pool.Kubernetes.Version, maintainedShoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile); reason != "" {
+				skippedUpdates = append(skippedUpdates, fmt.Sprintf("Worker pool %q: %s", pool.Name, reason))
+			}
 		}
 
 		if workerKubernetesUpdate != nil {
@@ -215,8 +519,59 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 			}
 			result.isSuccessful = workerKubernetesUpdate.isSuccessful
 			result.description = workerKubernetesUpdate.description
+			result.isForceful = workerKubernetesUpdate.isForceful
+			result.fromVersion = workerKubernetesUpdate.fromVersion
+			result.toVersion = workerKubernetesUpdate.toVersion
 			workerToKubernetesUpdate[pool.Name] = result
+
+			// When the pool version was force-updated across a minor boundary, settle version-specific kubelet
+			// settings that the new version would reject.
+			if workerKubernetesUpdate.isSuccessful {
+				if newPoolVersion, err := semver.NewVersion(*maintainedShoot.Spec.Provider.Workers[i].Kubernetes.Version); err == nil &&
+					(newPoolVersion.Major() > poolKubernetesVersion.Major() || newPoolVersion.Minor() > poolKubernetesVersion.Minor()) {
+					operations = append(operations, maintainWorkerPoolKubeletSettings(&maintainedShoot.Spec.Provider.Workers[i], newPoolVersion)...)
+				}
+			}
 		}
+
+		changes = append(changes, r.finishChange(change))
+	}
+
+	var healthNotes []string
+	kubernetesControlPlaneUpdate, healthNotes = deferUpdatesForUnhealthyShoot(shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate)
+	operations = append(operations, healthNotes...)
+	skippedUpdates = append(skippedUpdates, healthNotes...)
+
+	var freezeNotes []string
+	kubernetesControlPlaneUpdate, freezeNotes = r.deferUpdatesDuringFreeze(shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate)
+	operations = append(operations, freezeNotes...)
+	skippedUpdates = append(skippedUpdates, freezeNotes...)
+
+	var budgetNotes []string
+	kubernetesControlPlaneUpdate, budgetNotes = r.deferDisruptiveUpdates(shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate)
+	operations = append(operations, budgetNotes...)
+	skippedUpdates = append(skippedUpdates, budgetNotes...)
+
+	var policyNotes []string
+	kubernetesControlPlaneUpdate, policyNotes = r.applyPolicyDecisions(ctx, shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate)
+	operations = append(operations, policyNotes...)
+
+	for _, pool := range r.deferNonCanaryUpdates(shoot, maintainedShoot, workerToKubernetesUpdate, workerToMachineImageUpdate) {
+		note := fmt.Sprintf("Deferred update of worker pool %q to a subsequent maintenance window until the canary worker pool update was rolled out", pool)
+		operations = append(operations, note)
+		skippedUpdates = append(skippedUpdates, note)
+	}
+
+	recordVersionUpdateMetrics(kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate)
+
+	// collect updates which are available but remain unapplied because auto-update is disabled, so that operators get
+	// visibility into them without enabling the update itself
+	unappliedUpdates := availableUpdates(maintainedShoot, cloudProfile)
+
+	// Restore the Shoot's own maintenance section so that settings inherited from a referenced policy are only used
+	// for the update decisions above but never persisted on the Shoot.
+	if policy != nil {
+		maintainedShoot.Spec.Maintenance = shoot.Spec.Maintenance.DeepCopy()
 	}
 
 	operation := maintainOperation(maintainedShoot)
@@ -224,6 +579,12 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 		operations = append(operations, fmt.Sprintf("Added %q operation annotation", operation))
 	}
 
+	if r.featureEnabled(FeaturePlanConfigMap) {
+		if err := r.updatePlanConfigMap(ctx, shoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate); err != nil {
+			return fmt.Errorf("failed to update maintenance plan ConfigMap: %w", err)
+		}
+	}
+
 	requirePatch := len(operations) > 0 || kubernetesControlPlaneUpdate != nil || len(workerToKubernetesUpdate) > 0 || len(workerToMachineImageUpdate) > 0
 	if requirePatch {
 		patch := client.MergeFrom(shoot.DeepCopy())
@@ -244,6 +605,11 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 			Description:   description,
 			TriggeredTime: metav1.Time{Time: r.Clock.Now()},
 			State:         gardencorev1beta1.LastOperationStateProcessing,
+			Changes:       changes,
+			// record the observed CloudProfile state so that a maintenance decision can be correlated with the
+			// exact version lists it was based on (e.g. when debugging propagation lag of profile updates)
+			CloudProfileResourceVersion: pointer.String(cloudProfile.ResourceVersion),
+			AvailableUpdates:            unappliedUpdates,
 		}
 
 		// if any maintenance operation failed, set the status to 'Failed' and retry in the next maintenance cycle
@@ -277,6 +643,20 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 			return nil
 		}
 
+		if err := r.Client.Status().Patch(ctx, shoot, patch); err != nil {
+			return err
+		}
+	} else if len(unappliedUpdates) > 0 {
+		// even when no maintenance operation was performed, report available-but-unapplied updates for visibility
+		patch := client.MergeFrom(shoot.DeepCopy())
+		if shoot.Status.LastMaintenance == nil {
+			shoot.Status.LastMaintenance = &gardencorev1beta1.LastMaintenance{
+				Description:   "Maintenance performed no operations",
+				TriggeredTime: metav1.Time{Time: r.Clock.Now()},
+				State:         gardencorev1beta1.LastOperationStateSucceeded,
+			}
+		}
+		shoot.Status.LastMaintenance.AvailableUpdates = unappliedUpdates
 		if err := r.Client.Status().Patch(ctx, shoot, patch); err != nil {
 			return err
 		}
@@ -314,11 +694,49 @@ func (r *Reconciler) reconcile(ctx context.Context, log logr.Logger, shoot *gard
 
 	r.recordMaintenanceEventsForPool(workerToKubernetesUpdate, shoot, gardencorev1beta1.ShootEventK8sVersionMaintenance, "Kubernetes")
 	r.recordMaintenanceEventsForPool(workerToMachineImageUpdate, shoot, gardencorev1beta1.ShootEventImageVersionMaintenance, "Machine image")
+	r.recordSkippedUpdateEvents(shoot, skippedUpdates)
+
+	r.notifyForcefulUpdates(ctx, log, shoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate)
 
 	log.Info("Shoot maintenance completed")
 	return nil
 }
 
+// notifyForcefulUpdates reports all successfully applied force-updates to the configured notification webhook.
+// Notification failures are only logged because the maintenance itself already succeeded.
+func (r *Reconciler) notifyForcefulUpdates(ctx context.Context, log logr.Logger, shoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) {
+	if r.Notifier == nil {
+		return
+	}
+
+	notify := func(target string, result updateResult) {
+		if !result.isSuccessful || !result.isForceful {
+			return
+		}
+
+		if err := r.Notifier.Notify(ctx, ForceUpdateNotification{
+			Shoot:       shoot.Name,
+			Namespace:   shoot.Namespace,
+			Target:      target,
+			FromVersion: result.fromVersion,
+			ToVersion:   result.toVersion,
+			Reason:      result.reason,
+		}); err != nil {
+			log.Error(err, "Failed to notify webhook about force-update", "target", target)
+		}
+	}
+
+	if kubernetesControlPlaneUpdate != nil {
+		notify("control-plane/kubernetes", *kubernetesControlPlaneUpdate)
+	}
+	for worker, result := range workerToKubernetesUpdate {
+		notify(fmt.Sprintf("worker-pool/%s/kubernetes", worker), result)
+	}
+	for worker, result := range workerToMachineImageUpdate {
+		notify(fmt.Sprintf("worker-pool/%s/machine-image", worker), result)
+	}
+}
+
 // buildMaintenanceMessages builds a combined message containing the performed maintenance operations over all worker pools. If the maintenance operation failed, the description
 // contains an indication for the failure and the reason the update was triggered. Details for failed maintenance operations are returned in the second return string.
 func buildMaintenanceMessages(kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate map[string]updateResult, workerToMachineImageUpdate map[string]updateResult) (string, string) {
@@ -381,61 +799,422 @@ func (r *Reconciler) recordMaintenanceEventsForPool(workerToUpdateResult map[str
 			continue
 		}
 
-		r.Recorder.Eventf(shoot, corev1.EventTypeWarning, eventType, "%s", fmt.Sprintf("Worker pool %q: %s version maintenance failed. Reason for update: %s. Error: %v",
-			worker, maintenanceType, reason.reason, reason.description))
+		r.Recorder.Eventf(shoot, corev1.EventTypeWarning, eventType, "%s", fmt.Sprintf("Worker pool %q: %s version maintenance failed. Reason for update: %s. Error: %v",
+			worker, maintenanceType, reason.reason, reason.description))
+	}
+}
+
+// recordSkippedUpdateEvents emits a dedicated event per intentionally skipped update so that operators can tell from
+// the events alone why a maintenance window passed without changes.
+func (r *Reconciler) recordSkippedUpdateEvents(shoot *gardencorev1beta1.Shoot, skippedUpdates []string) {
+	for _, skip := range skippedUpdates {
+		r.Recorder.Eventf(shoot, corev1.EventTypeNormal, gardencorev1beta1.ShootEventMaintenanceUpdateSkipped, "%s", skip)
+	}
+}
+
+// kubernetesVersionUpdateSkipReason explains why a Kubernetes version maintenance intentionally did not yield an
+// update result. It returns an empty string when no reason can be determined.
+func kubernetesVersionUpdateSkipReason(kubernetesVersion string, autoUpdate bool, profile *gardencorev1beta1.CloudProfile) string {
+	shouldBeUpdated, _, _, err := shouldKubernetesVersionBeUpdated(kubernetesVersion, autoUpdate, profile)
+	if err != nil {
+		return ""
+	}
+
+	if !shouldBeUpdated {
+		return fmt.Sprintf("Skipped Kubernetes version update: auto-update is disabled and version %q is not expired", kubernetesVersion)
+	}
+
+	return fmt.Sprintf("Skipped Kubernetes version update: no higher suitable version than %q found in the CloudProfile", kubernetesVersion)
+}
+
+// machineImageUpdateSkipReasons explains for every worker pool whose machine image maintenance did not yield an
+// update result why the update was intentionally skipped.
+func machineImageUpdateSkipReasons(shoot *gardencorev1beta1.Shoot, workerToMachineImageUpdate map[string]updateResult) []string {
+	var (
+		reasons                 []string
+		imageNamesForAutoUpdate = machineImageNamesForAutoUpdate(shoot)
+	)
+
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if worker.Machine.Image == nil || worker.Machine.Image.Version == nil {
+			continue
+		}
+		if _, ok := workerToMachineImageUpdate[worker.Name]; ok {
+			continue
+		}
+
+		autoUpdate := pointer.BoolDeref(shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion, false)
+		if imageNamesForAutoUpdate != nil && !imageNamesForAutoUpdate.Has(worker.Machine.Image.Name) {
+			autoUpdate = false
+		}
+
+		if !autoUpdate {
+			reasons = append(reasons, fmt.Sprintf("Worker pool %q: Skipped machine image version update: auto-update is disabled and version %q is not expired", worker.Name, *worker.Machine.Image.Version))
+			continue
+		}
+
+		reasons = append(reasons, fmt.Sprintf("Worker pool %q: Skipped machine image version update: no higher suitable version than %q found in the CloudProfile", worker.Name, *worker.Machine.Image.Version))
+	}
+
+	return reasons
+}
+
+func maintainOperation(shoot *gardencorev1beta1.Shoot) string {
+	var operation string
+	if hasMaintainNowAnnotation(shoot) {
+		delete(shoot.Annotations, v1beta1constants.GardenerOperation)
+	}
+
+	if shoot.Status.LastOperation == nil {
+		return ""
+	}
+
+	switch {
+	case shoot.Status.LastOperation.State == gardencorev1beta1.LastOperationStateFailed:
+		if needsRetry(shoot) {
+			metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.ShootOperationRetry)
+			delete(shoot.Annotations, v1beta1constants.FailedShootNeedsRetryOperation)
+		}
+	default:
+		operation = getOperation(shoot)
+		metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerOperation, operation)
+		delete(shoot.Annotations, v1beta1constants.GardenerMaintenanceOperation)
+	}
+
+	if operation == v1beta1constants.GardenerOperationReconcile {
+		return ""
+	}
+
+	return operation
+}
+
+func maintainTasks(shoot *gardencorev1beta1.Shoot, config config.ShootMaintenanceControllerConfiguration) {
+	controllerutils.AddTasks(shoot.Annotations,
+		v1beta1constants.ShootTaskDeployInfrastructure,
+		v1beta1constants.ShootTaskDeployDNSRecordInternal,
+		v1beta1constants.ShootTaskDeployDNSRecordExternal,
+		v1beta1constants.ShootTaskDeployDNSRecordIngress,
+	)
+
+	if pointer.BoolDeref(config.EnableShootControlPlaneRestarter, false) {
+		controllerutils.AddTasks(shoot.Annotations, v1beta1constants.ShootTaskRestartControlPlanePods)
+	}
+
+	if pointer.BoolDeref(config.EnableShootCoreAddonRestarter, false) {
+		controllerutils.AddTasks(shoot.Annotations, v1beta1constants.ShootTaskRestartCoreAddons)
+	}
+}
+
+// applyPolicyDecisions consults the configured PolicyDecider for every planned version update. Denied updates are
+// reverted, allowed updates may get their target version overridden. It returns the possibly adjusted control plane
+// update and notes about denied or overridden updates.
+func (r *Reconciler) applyPolicyDecisions(ctx context.Context, shoot, maintainedShoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) (*updateResult, []string) {
+	if r.PolicyDecider == nil || !r.featureEnabled(FeaturePolicyDecisions) {
+		return kubernetesControlPlaneUpdate, nil
+	}
+
+	var notes []string
+
+	decide := func(target string, result updateResult, apply func(version string), revert func()) bool {
+		if !result.isSuccessful {
+			return true
+		}
+
+		decision := r.PolicyDecider.Decide(ctx, shoot, target, result.fromVersion, result.toVersion)
+		if !decision.Allowed {
+			revert()
+			note := fmt.Sprintf("Update of %q from %q to %q was denied by policy", target, result.fromVersion, result.toVersion)
+			if decision.Reason != "" {
+				note = fmt.Sprintf("%s: %s", note, decision.Reason)
+			}
+			notes = append(notes, note)
+			return false
+		}
+
+		if decision.OverrideVersion != nil && *decision.OverrideVersion != result.toVersion {
+			apply(*decision.OverrideVersion)
+			notes = append(notes, fmt.Sprintf("Target version of %q was overridden by policy from %q to %q", target, result.toVersion, *decision.OverrideVersion))
+		}
+		return true
+	}
+
+	if kubernetesControlPlaneUpdate != nil {
+		if !decide("control-plane/kubernetes", *kubernetesControlPlaneUpdate,
+			func(version string) { maintainedShoot.Spec.Kubernetes.Version = version },
+			func() { maintainedShoot.Spec.Kubernetes.Version = shoot.Spec.Kubernetes.Version },
+		) {
+			kubernetesControlPlaneUpdate = nil
+		}
+	}
+
+	for i, worker := range shoot.Spec.Provider.Workers {
+		i, worker := i, worker
+
+		if result, ok := workerToKubernetesUpdate[worker.Name]; ok {
+			if !decide(fmt.Sprintf("worker-pool/%s/kubernetes", worker.Name), result,
+				func(version string) { maintainedShoot.Spec.Provider.Workers[i].Kubernetes.Version = &version },
+				func() { maintainedShoot.Spec.Provider.Workers[i].Kubernetes = worker.Kubernetes.DeepCopy() },
+			) {
+				delete(workerToKubernetesUpdate, worker.Name)
+			}
+		}
+
+		if result, ok := workerToMachineImageUpdate[worker.Name]; ok {
+			if !decide(fmt.Sprintf("worker-pool/%s/machine-image", worker.Name), result,
+				func(version string) { maintainedShoot.Spec.Provider.Workers[i].Machine.Image.Version = &version },
+				func() { maintainedShoot.Spec.Provider.Workers[i].Machine.Image = worker.Machine.Image.DeepCopy() },
+			) {
+				delete(workerToMachineImageUpdate, worker.Name)
+			}
+		}
+	}
+
+	return kubernetesControlPlaneUpdate, notes
+}
+
+// deferNonCanaryUpdates defers updates of non-canary worker pools unless the FeatureCanaryUpdates gate is enabled.
+func (r *Reconciler) deferNonCanaryUpdates(shoot, maintainedShoot *gardencorev1beta1.Shoot, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) []string {
+	if !r.featureEnabled(FeatureCanaryUpdates) {
+		return nil
+	}
+	return deferNonCanaryUpdates(shoot, maintainedShoot, workerToKubernetesUpdate, workerToMachineImageUpdate)
+}
+
+// deferNonCanaryUpdates reverts version updates of non-canary worker pools when a canary worker pool is updated in
+// this maintenance. The reverted pools are updated in a subsequent maintenance window once the canary pool is
+// up-to-date. It returns the names of the deferred worker pools.
+func deferNonCanaryUpdates(shoot, maintainedShoot *gardencorev1beta1.Shoot, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) []string {
+	canaryPools := sets.New[string]()
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if worker.Maintenance != nil && worker.Maintenance.Canary {
+			canaryPools.Insert(worker.Name)
+		}
+	}
+	if canaryPools.Len() == 0 {
+		return nil
+	}
+
+	canaryUpdated := false
+	for _, updates := range []map[string]updateResult{workerToKubernetesUpdate, workerToMachineImageUpdate} {
+		for poolName, result := range updates {
+			if canaryPools.Has(poolName) && result.isSuccessful {
+				canaryUpdated = true
+			}
+		}
+	}
+	if !canaryUpdated {
+		return nil
+	}
+
+	var deferredPools []string
+	for i, worker := range shoot.Spec.Provider.Workers {
+		if canaryPools.Has(worker.Name) {
+			continue
+		}
+
+		_, hasKubernetesUpdate := workerToKubernetesUpdate[worker.Name]
+		_, hasMachineImageUpdate := workerToMachineImageUpdate[worker.Name]
+		if !hasKubernetesUpdate && !hasMachineImageUpdate {
+			continue
+		}
+
+		maintainedShoot.Spec.Provider.Workers[i].Machine.Image = worker.Machine.Image.DeepCopy()
+		maintainedShoot.Spec.Provider.Workers[i].Kubernetes = worker.Kubernetes.DeepCopy()
+		delete(workerToKubernetesUpdate, worker.Name)
+		delete(workerToMachineImageUpdate, worker.Name)
+		deferredPools = append(deferredPools, worker.Name)
+	}
+
+	slices.Sort(deferredPools)
+	return deferredPools
+}
+
+// criticalHealthConditionTypes are the Shoot condition types which mark the Shoot as critically unhealthy for
+// the maintenance health precheck when their status is False.
+var criticalHealthConditionTypes = []gardencorev1beta1.ConditionType{
+	gardencorev1beta1.ShootAPIServerAvailable,
+	gardencorev1beta1.ShootControlPlaneHealthy,
+}
+
+// isShootCriticallyUnhealthy returns true when any of the critical health conditions of the Shoot is False.
+func isShootCriticallyUnhealthy(shoot *gardencorev1beta1.Shoot) bool {
+	for _, conditionType := range criticalHealthConditionTypes {
+		if condition := v1beta1helper.GetCondition(shoot.Status.Conditions, conditionType); condition != nil && condition.Status == gardencorev1beta1.ConditionFalse {
+			return true
+		}
+	}
+	return false
+}
+
+// deferUpdatesForUnhealthyShoot reverts planned version updates when the Shoot is critically unhealthy.
+// Updating an already-degraded cluster can worsen the situation, so only force updates caused by a truly
+// expired version (which must not be postponed) are kept. It returns the possibly adjusted control plane
+// update and a note for every deferred update.
+func deferUpdatesForUnhealthyShoot(shoot, maintainedShoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) (*updateResult, []string) {
+	if !isShootCriticallyUnhealthy(shoot) {
+		return kubernetesControlPlaneUpdate, nil
+	}
+
+	shouldDefer := func(result updateResult) (bool, string) {
+		if result.isForceful {
+			return false, ""
+		}
+		return true, "the Shoot is critically unhealthy"
+	}
+
+	return revertPlannedUpdates(shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate, shouldDefer)
+}
+
+// isDisruptiveUpdate reports whether the update changes the major or minor version.
+func isDisruptiveUpdate(result updateResult) bool {
+	fromVersion, err := semver.NewVersion(result.fromVersion)
+	if err != nil {
+		return false
+	}
+	toVersion, err := semver.NewVersion(result.toVersion)
+	if err != nil {
+		return false
+	}
+	return toVersion.Major() != fromVersion.Major() || toVersion.Minor() != fromVersion.Minor()
+}
+
+// isPatchLevelUpdate reports whether both versions share the same major and minor version, i.e. whether the
+// update only changes the patch level.
+func isPatchLevelUpdate(fromVersion, toVersion string) bool {
+	from, err := semver.NewVersion(fromVersion)
+	if err != nil {
+		return false
+	}
+	to, err := semver.NewVersion(toVersion)
+	if err != nil {
+		return false
+	}
+	return from.Major() == to.Major() && from.Minor() == to.Minor()
+}
+
+// deferDisruptiveUpdates reverts disruptive (minor or major) version updates when the last maintenance of the
+// Shoot was triggered within the configured MinDisruptiveUpdateInterval. This spaces out back-to-back
+// disruptive updates (e.g. consecutive force updates to the next minor) while patch-level updates remain
+// frequent. It returns the possibly adjusted control plane update and a note for every deferred update.
+func (r *Reconciler) deferDisruptiveUpdates(shoot, maintainedShoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) (*updateResult, []string) {
+	if r.Config.MinDisruptiveUpdateInterval == nil || shoot.Status.LastMaintenance == nil {
+		return kubernetesControlPlaneUpdate, nil
+	}
+
+	nextAllowed := shoot.Status.LastMaintenance.TriggeredTime.Add(r.Config.MinDisruptiveUpdateInterval.Duration)
+	if !r.Clock.Now().Before(nextAllowed) {
+		return kubernetesControlPlaneUpdate, nil
+	}
+
+	shouldDefer := func(result updateResult) (bool, string) {
+		if !isDisruptiveUpdate(result) {
+			return false, ""
+		}
+		return true, fmt.Sprintf("the last maintenance was triggered at %s which is within the minimum interval of %s between disruptive updates", shoot.Status.LastMaintenance.TriggeredTime.UTC().Format(time.RFC3339), r.Config.MinDisruptiveUpdateInterval.Duration)
+	}
+
+	return revertPlannedUpdates(shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate, shouldDefer)
+}
+
+// activeFreezeWindow returns the configured freeze window which the current time falls into, or nil when no
+// change freeze is active.
+func (r *Reconciler) activeFreezeWindow() *config.MaintenanceFreezeWindow {
+	now := r.Clock.Now()
+	for _, window := range r.Config.FreezeWindows {
+		window := window
+		if !now.Before(window.Begin.Time) && now.Before(window.End.Time) {
+			return &window
+		}
+	}
+	return nil
+}
+
+// deferUpdatesDuringFreeze reverts planned version updates while a configured change freeze window is active.
+// Force updates of truly expired versions are only kept when the active window explicitly allows them. It
+// returns the possibly adjusted control plane update and a note for every deferred update.
+func (r *Reconciler) deferUpdatesDuringFreeze(shoot, maintainedShoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult) (*updateResult, []string) {
+	window := r.activeFreezeWindow()
+	if window == nil {
+		return kubernetesControlPlaneUpdate, nil
+	}
+
+	shouldDefer := func(result updateResult) (bool, string) {
+		if window.AllowExpiredForceUpdates && result.isForceful {
+			return false, ""
+		}
+		return true, fmt.Sprintf("a change freeze is active from %s until %s", window.Begin.UTC().Format(time.RFC3339), window.End.UTC().Format(time.RFC3339))
 	}
+
+	return revertPlannedUpdates(shoot, maintainedShoot, kubernetesControlPlaneUpdate, workerToKubernetesUpdate, workerToMachineImageUpdate, shouldDefer)
 }
 
-func maintainOperation(shoot *gardencorev1beta1.Shoot) string {
-	var operation string
-	if hasMaintainNowAnnotation(shoot) {
-		delete(shoot.Annotations, v1beta1constants.GardenerOperation)
+// revertPlannedUpdates reverts every successfully planned version update for which <shouldDefer> returns true,
+// removing it from the respective update map. It returns the possibly adjusted control plane update and a note
+// for every reverted update containing the reason returned by <shouldDefer>.
+func revertPlannedUpdates(shoot, maintainedShoot *gardencorev1beta1.Shoot, kubernetesControlPlaneUpdate *updateResult, workerToKubernetesUpdate, workerToMachineImageUpdate map[string]updateResult, shouldDefer func(updateResult) (bool, string)) (*updateResult, []string) {
+	var notes []string
+
+	deferUpdate := func(target string, result updateResult, revert func()) bool {
+		if !result.isSuccessful {
+			return false
+		}
+		deferred, reason := shouldDefer(result)
+		if !deferred {
+			return false
+		}
+		revert()
+		notes = append(notes, fmt.Sprintf("Deferred update of %q from %q to %q to a subsequent maintenance window because %s", target, result.fromVersion, result.toVersion, reason))
+		return true
 	}
 
-	if shoot.Status.LastOperation == nil {
-		return ""
+	if kubernetesControlPlaneUpdate != nil && deferUpdate("control-plane/kubernetes", *kubernetesControlPlaneUpdate,
+		func() { maintainedShoot.Spec.Kubernetes.Version = shoot.Spec.Kubernetes.Version },
+	) {
+		kubernetesControlPlaneUpdate = nil
 	}
 
-	switch {
-	case shoot.Status.LastOperation.State == gardencorev1beta1.LastOperationStateFailed:
-		if needsRetry(shoot) {
-			metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.ShootOperationRetry)
-			delete(shoot.Annotations, v1beta1constants.FailedShootNeedsRetryOperation)
+	for i, worker := range shoot.Spec.Provider.Workers {
+		i, worker := i, worker
+
+		if result, ok := workerToKubernetesUpdate[worker.Name]; ok && deferUpdate(fmt.Sprintf("worker-pool/%s/kubernetes", worker.Name), result,
+			func() { maintainedShoot.Spec.Provider.Workers[i].Kubernetes = worker.Kubernetes.DeepCopy() },
+		) {
+			delete(workerToKubernetesUpdate, worker.Name)
 		}
-	default:
-		operation = getOperation(shoot)
-		metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerOperation, operation)
-		delete(shoot.Annotations, v1beta1constants.GardenerMaintenanceOperation)
-	}
 
-	if operation == v1beta1constants.GardenerOperationReconcile {
-		return ""
+		if result, ok := workerToMachineImageUpdate[worker.Name]; ok && deferUpdate(fmt.Sprintf("worker-pool/%s/machine-image", worker.Name), result,
+			func() { maintainedShoot.Spec.Provider.Workers[i].Machine.Image = worker.Machine.Image.DeepCopy() },
+		) {
+			delete(workerToMachineImageUpdate, worker.Name)
+		}
 	}
 
-	return operation
+	return kubernetesControlPlaneUpdate, notes
 }
 
-func maintainTasks(shoot *gardencorev1beta1.Shoot, config config.ShootMaintenanceControllerConfiguration) {
-	controllerutils.AddTasks(shoot.Annotations,
-		v1beta1constants.ShootTaskDeployInfrastructure,
-		v1beta1constants.ShootTaskDeployDNSRecordInternal,
-		v1beta1constants.ShootTaskDeployDNSRecordExternal,
-		v1beta1constants.ShootTaskDeployDNSRecordIngress,
-	)
-
-	if pointer.BoolDeref(config.EnableShootControlPlaneRestarter, false) {
-		controllerutils.AddTasks(shoot.Annotations, v1beta1constants.ShootTaskRestartControlPlanePods)
+// machineImageNamesForAutoUpdate returns the set of machine image names the auto-update is restricted to via the
+// respective annotation. It returns nil when no restriction is configured, i.e. all images are auto-updated.
+func machineImageNamesForAutoUpdate(shoot *gardencorev1beta1.Shoot) sets.Set[string] {
+	value, ok := shoot.Annotations[AnnotationAutoUpdateMachineImageNames]
+	if !ok {
+		return nil
 	}
 
-	if pointer.BoolDeref(config.EnableShootCoreAddonRestarter, false) {
-		controllerutils.AddTasks(shoot.Annotations, v1beta1constants.ShootTaskRestartCoreAddons)
+	imageNames := sets.New[string]()
+	for _, imageName := range strings.Split(value, ",") {
+		imageNames.Insert(strings.TrimSpace(imageName))
 	}
+	return imageNames
 }
 
-// maintainMachineImages updates the machine images of a Shoot's worker pools if necessary
-func maintainMachineImages(log logr.Logger, shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) (map[string]updateResult, error) {
+// maintainMachineImages updates the machine images of a Shoot's worker pools if necessary. When <migrateCRI> is
+// true, a worker pool using the docker container runtime is migrated to containerd when the image update is only
+// blocked because all suitable higher versions dropped support for docker.
+func maintainMachineImages(log logr.Logger, shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile, migrateCRI bool) (map[string]updateResult, error) {
 	maintenanceResults := make(map[string]updateResult)
 
+	imageNamesForAutoUpdate := machineImageNamesForAutoUpdate(shoot)
+
 	controlPlaneVersion, err := semver.NewVersion(shoot.Spec.Kubernetes.Version)
 	if err != nil {
 		return nil, err
@@ -450,26 +1229,74 @@ func maintainMachineImages(log logr.Logger, shoot *gardencorev1beta1.Shoot, clou
 			return nil, err
 		}
 
+		// an update strategy configured on the worker's machine image takes precedence over the CloudProfile default
+		if workerImage.UpdateStrategy != nil {
+			machineImageFromCloudProfile.UpdateStrategy = workerImage.UpdateStrategy
+		}
+
 		kubeletVersion, err := v1beta1helper.CalculateEffectiveKubernetesVersion(controlPlaneVersion, worker.Kubernetes)
 		if err != nil {
 			return nil, err
 		}
 
-		filteredMachineImageVersionsFromCloudProfile := filterForArchitecture(&machineImageFromCloudProfile, worker.Machine.Architecture)
-		filteredMachineImageVersionsFromCloudProfile = filterForCRI(filteredMachineImageVersionsFromCloudProfile, worker.CRI)
+		filteredMachineImageVersionsFromCloudProfile := ResolveImageForCRI(&machineImageFromCloudProfile, worker.Machine.Architecture, worker.CRI)
 		filteredMachineImageVersionsFromCloudProfile = filterForKubeleteVersionConstraint(filteredMachineImageVersionsFromCloudProfile, kubeletVersion)
 
+		autoUpdate := *shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion
+		if imageNamesForAutoUpdate != nil && !imageNamesForAutoUpdate.Has(workerImage.Name) {
+			autoUpdate = false
+		}
+
 		// first check if the machine image version should be updated
-		shouldBeUpdated, reason, isExpired := shouldMachineImageVersionBeUpdated(workerImage, filteredMachineImageVersionsFromCloudProfile, *shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion)
+		shouldBeUpdated, reason, isExpired := shouldMachineImageVersionBeUpdated(workerImage, filteredMachineImageVersionsFromCloudProfile, autoUpdate)
 		if !shouldBeUpdated {
 			continue
 		}
 
-		updatedMachineImageVersion, err := determineMachineImageVersion(workerImage, filteredMachineImageVersionsFromCloudProfile, isExpired)
+		// the configured maximum version only bounds automatic updates; force-updates of expired versions work on the
+		// unfiltered version list and may exceed it
+		versionsForUpdate := filteredMachineImageVersionsFromCloudProfile
+		if workerImage.MaximumVersion != nil && !isExpired {
+			versionsForUpdate, err = filterForMaximumVersion(filteredMachineImageVersionsFromCloudProfile, *workerImage.MaximumVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		updatedMachineImageVersion, err := determineMachineImageVersion(workerImage, versionsForUpdate, isExpired)
+		if errors.Is(err, ErrNoHigherVersionAvailable) && migrateCRI && usesDockerCRI(worker.CRI) {
+			// The update might only be blocked because all suitable higher versions dropped support for docker.
+			// In this case migrate the worker pool to containerd instead of failing the maintenance.
+			containerdCRI := &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}
+			if worker.CRI != nil {
+				containerdCRI.ContainerRuntimes = worker.CRI.ContainerRuntimes
+			}
+
+			versionsForContainerD := filterForKubeleteVersionConstraint(ResolveImageForCRI(&machineImageFromCloudProfile, worker.Machine.Architecture, containerdCRI), kubeletVersion)
+			if versionForContainerD, retryErr := determineMachineImageVersion(workerImage, versionsForContainerD, isExpired); retryErr == nil && versionForContainerD != "" {
+				workerLog.Info("MachineImage will be updated and the worker pool migrated to containerd because the new version no longer supports docker", "newVersion", versionForContainerD, "reason", reason)
+				maintenanceResults[worker.Name] = updateResult{
+					description:  fmt.Sprintf("Updated machine image %q from %q to %q and migrated the container runtime of worker pool %q from %q to %q because the new version no longer supports %q", workerImage.Name, *workerImage.Version, versionForContainerD, worker.Name, gardencorev1beta1.CRINameDocker, gardencorev1beta1.CRINameContainerD, gardencorev1beta1.CRINameDocker),
+					reason:       reason,
+					isSuccessful: true,
+					isForceful:   isExpired,
+					fromVersion:  *workerImage.Version,
+					toVersion:    versionForContainerD,
+				}
+
+				shoot.Spec.Provider.Workers[i].CRI = containerdCRI
+				shoot.Spec.Provider.Workers[i].Machine.Image.Version = &versionForContainerD
+				continue
+			}
+		}
 		if err != nil {
 			log.Error(err, "Maintenance of machine image failed", "workerPool", worker.Name, "machineImage", workerImage.Name)
+			description := fmt.Sprintf("failed to update machine image %q: %s", workerImage.Name, err.Error())
+			if errors.Is(err, ErrNoHigherVersionAvailable) {
+				description = noHigherVersionDescription(*workerImage.Version)
+			}
 			maintenanceResults[worker.Name] = updateResult{
-				description:  fmt.Sprintf("failed to update machine image %q: %s", workerImage.Name, err.Error()),
+				description:  description,
 				reason:       reason,
 				isSuccessful: false,
 			}
@@ -480,11 +1307,34 @@ func maintainMachineImages(log logr.Logger, shoot *gardencorev1beta1.Shoot, clou
 			continue
 		}
 
+		// With the strategies "minor" and "major" an expired version is first caught up to the latest patch
+		// version of its minor before the actual boundary-crossing force update happens in a subsequent
+		// maintenance. Report this as its own reason so that operators understand the intermediate step.
+		if isExpired {
+			latestPatchInMinor, err := versionutils.IsLatestPatchInMinor(*workerImage.Version, filteredMachineImageVersionsFromCloudProfile.Versions, pointer.StringDeref(worker.Machine.Architecture, ""))
+			if err != nil {
+				return nil, err
+			}
+			if !latestPatchInMinor && isPatchLevelUpdate(*workerImage.Version, updatedMachineImageVersion) {
+				reason = ReasonMachineImagePatchCatchUp.WithUpdateStrategy(*filteredMachineImageVersionsFromCloudProfile.UpdateStrategy)
+			}
+		}
+
+		description := fmt.Sprintf("Updated machine image %q from %q to %q", workerImage.Name, *workerImage.Version, updatedMachineImageVersion)
+		if workerImage.MaximumVersion != nil && isExpired {
+			if exceedsMaximum, cmpErr := versionutils.CompareVersions(updatedMachineImageVersion, ">", *workerImage.MaximumVersion); cmpErr == nil && exceedsMaximum {
+				description += fmt.Sprintf(". The configured maximum version %q was exceeded because the current version is expired", *workerImage.MaximumVersion)
+			}
+		}
+
 		workerLog.Info("MachineImage will be updated", "newVersion", updatedMachineImageVersion, "reason", reason)
 		maintenanceResults[worker.Name] = updateResult{
-			description:  fmt.Sprintf("Updated machine image %q from %q to %q", workerImage.Name, *workerImage.Version, updatedMachineImageVersion),
+			description:  description,
 			reason:       reason,
 			isSuccessful: true,
+			isForceful:   isExpired,
+			fromVersion:  *workerImage.Version,
+			toVersion:    updatedMachineImageVersion,
 		}
 
 		// update the machine image version
@@ -506,8 +1356,12 @@ func maintainKubernetesVersion(log logr.Logger, kubernetesVersion string, autoUp
 
 	updatedKubernetesVersion, err := determineKubernetesVersion(kubernetesVersion, profile, isExpired)
 	if err != nil {
+		description := fmt.Sprintf("could not determine higher suitable version than %q: %v", kubernetesVersion, err)
+		if errors.Is(err, ErrNoHigherVersionAvailable) {
+			description = noHigherVersionDescription(kubernetesVersion)
+		}
 		return &updateResult{
-			description:  fmt.Sprintf("could not determine higher suitable version than %q: %v", kubernetesVersion, err),
+			description:  description,
 			reason:       reason,
 			isSuccessful: false,
 		}, err
@@ -517,6 +1371,17 @@ func maintainKubernetesVersion(log logr.Logger, kubernetesVersion string, autoUp
 		return nil, nil
 	}
 
+	// defensive check: never downgrade the Kubernetes version, e.g. when a CloudProfile edit removed the current
+	// version and the resolver could only determine a lower one
+	if err := ensureNoDowngrade(kubernetesVersion, updatedKubernetesVersion); err != nil {
+		log.Info("Refusing Kubernetes version update", "reason", err.Error())
+		return &updateResult{
+			description:  err.Error(),
+			reason:       reason,
+			isSuccessful: false,
+		}, nil
+	}
+
 	err = updateFunc(updatedKubernetesVersion)
 	if err != nil {
 		return &updateResult{
@@ -531,9 +1396,26 @@ func maintainKubernetesVersion(log logr.Logger, kubernetesVersion string, autoUp
 		description:  fmt.Sprintf("Updated Kubernetes version from %q to %q", kubernetesVersion, updatedKubernetesVersion),
 		reason:       reason,
 		isSuccessful: true,
+		isForceful:   isExpired,
+		fromVersion:  kubernetesVersion,
+		toVersion:    updatedKubernetesVersion,
 	}, nil
 }
 
+// ensureNoDowngrade returns an error with the WouldDowngrade reason when updating from <currentVersion> to
+// <determinedVersion> would be a downgrade. The version resolvers never select lower versions, so this guards against
+// future regressions and CloudProfile misconfigurations sneaking a lower version past the resolver.
+func ensureNoDowngrade(currentVersion, determinedVersion string) error {
+	downgrade, err := versionutils.CompareVersions(determinedVersion, "<", currentVersion)
+	if err != nil {
+		return err
+	}
+	if downgrade {
+		return fmt.Errorf("%s: refusing to update version %q to lower version %q determined by the update strategy", ReasonWouldDowngrade, currentVersion, determinedVersion)
+	}
+	return nil
+}
+
 func determineKubernetesVersion(kubernetesVersion string, profile *gardencorev1beta1.CloudProfile, isExpired bool) (string, error) {
 	getHigherVersionAutoUpdate := v1beta1helper.GetLatestVersionForPatchAutoUpdate
 	getHigherVersionForceUpdate := v1beta1helper.GetVersionForForcefulUpdateToConsecutiveMinor
@@ -551,27 +1433,163 @@ func shouldKubernetesVersionBeUpdated(kubernetesVersion string, autoUpdate bool,
 		return false, "", false, err
 	}
 
-	var updateReason string
 	if !versionExistsInCloudProfile {
-		updateReason = "Version does not exist in CloudProfile"
-		return true, updateReason, true, nil
+		return true, ReasonVersionMissingInCloudProfile.String(), true, nil
 	}
 
 	if ExpirationDateExpired(version.ExpirationDate) {
-		updateReason = "Kubernetes version expired - force update required"
-		return true, updateReason, true, nil
+		return true, ReasonKubernetesVersionExpired.String(), true, nil
 	}
 
 	if autoUpdate {
-		updateReason = "Automatic update of Kubernetes version configured"
-		return true, updateReason, false, nil
+		return true, ReasonKubernetesAutoUpdate.String(), false, nil
 	}
 
 	return false, "", false, nil
 }
 
+// ExpiredVersion describes a version configured in a Shoot which is expired in the respective CloudProfile.
+type ExpiredVersion struct {
+	// Version is the expired version.
+	Version string
+	// Target describes where the version is configured in the Shoot, e.g. the control plane or a worker pool.
+	Target string
+}
+
+// ExpiredVersions returns the versions configured in the given Shoot (control plane Kubernetes version, worker pool
+// Kubernetes versions, and worker pool machine image versions) which are expired in the given CloudProfile. Versions
+// which do not exist in the CloudProfile (anymore) are reported as expired as well, matching the checks which trigger
+// force updates during maintenance.
+func ExpiredVersions(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) ([]ExpiredVersion, error) {
+	var expiredVersions []ExpiredVersion
+
+	_, _, isExpired, err := shouldKubernetesVersionBeUpdated(shoot.Spec.Kubernetes.Version, false, cloudProfile)
+	if err != nil {
+		return nil, err
+	}
+	if isExpired {
+		expiredVersions = append(expiredVersions, ExpiredVersion{Version: shoot.Spec.Kubernetes.Version, Target: "control plane"})
+	}
+
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if worker.Kubernetes != nil && worker.Kubernetes.Version != nil {
+			_, _, isExpired, err := shouldKubernetesVersionBeUpdated(*worker.Kubernetes.Version, false, cloudProfile)
+			if err != nil {
+				return nil, err
+			}
+			if isExpired {
+				expiredVersions = append(expiredVersions, ExpiredVersion{Version: *worker.Kubernetes.Version, Target: fmt.Sprintf("worker pool %q (Kubernetes)", worker.Name)})
+			}
+		}
+
+		if workerImage := worker.Machine.Image; workerImage != nil && workerImage.Version != nil {
+			machineImageFromCloudProfile, err := determineMachineImage(cloudProfile, workerImage)
+			if err != nil {
+				return nil, err
+			}
+			if workerImage.UpdateStrategy != nil {
+				machineImageFromCloudProfile.UpdateStrategy = workerImage.UpdateStrategy
+			}
+
+			if _, _, isExpired := shouldMachineImageVersionBeUpdated(workerImage, &machineImageFromCloudProfile, false); isExpired {
+				expiredVersions = append(expiredVersions, ExpiredVersion{Version: *workerImage.Version, Target: fmt.Sprintf("worker pool %q (machine image %q)", worker.Name, workerImage.Name)})
+			}
+		}
+	}
+
+	return expiredVersions, nil
+}
+
+// nearestExpirationDate returns the soonest expiration date among the versions configured in the given Shoot
+// (control plane Kubernetes version, worker pool Kubernetes versions, and worker pool machine image versions), or nil
+// when none of them has an expiration date configured in the given CloudProfile.
+func nearestExpirationDate(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) *metav1.Time {
+	var nearest *metav1.Time
+
+	consider := func(timestamp *metav1.Time) {
+		if timestamp != nil && (nearest == nil || timestamp.Before(nearest)) {
+			nearest = timestamp
+		}
+	}
+
+	if exists, version, err := v1beta1helper.KubernetesVersionExistsInCloudProfile(cloudProfile, shoot.Spec.Kubernetes.Version); err == nil && exists {
+		consider(version.ExpirationDate)
+	}
+
+	for _, worker := range shoot.Spec.Provider.Workers {
+		if worker.Kubernetes != nil && worker.Kubernetes.Version != nil {
+			if exists, version, err := v1beta1helper.KubernetesVersionExistsInCloudProfile(cloudProfile, *worker.Kubernetes.Version); err == nil && exists {
+				consider(version.ExpirationDate)
+			}
+		}
+
+		if workerImage := worker.Machine.Image; workerImage != nil && workerImage.Version != nil {
+			machineImageFound, machineImage := v1beta1helper.DetermineMachineImageForName(cloudProfile, workerImage.Name)
+			if !machineImageFound {
+				continue
+			}
+
+			if versionExists, versionIndex := v1beta1helper.ShootMachineImageVersionExists(machineImage, *workerImage); versionExists {
+				consider(machineImage.Versions[versionIndex].ExpirationDate)
+			}
+		}
+	}
+
+	return nearest
+}
+
 func mustMaintainNow(shoot *gardencorev1beta1.Shoot, clock clock.Clock) bool {
-	return hasMaintainNowAnnotation(shoot) || gardenerutils.IsNowInEffectiveShootMaintenanceTimeWindow(shoot, clock)
+	return hasMaintainNowAnnotation(shoot) || hasForcedMaintainNowAnnotation(shoot) || maintenanceScopeForShoot(shoot) != scopeFull ||
+		(scheduleMatchesToday(shoot, clock) && gardenerutils.IsNowInEffectiveShootMaintenanceTimeWindow(shoot, clock))
+}
+
+// maintenanceScope describes which parts of the Shoot are maintained during a maintenance run.
+type maintenanceScope string
+
+const (
+	// scopeFull maintains both the control plane and the worker pools.
+	scopeFull maintenanceScope = "full shoot"
+	// scopeControlPlane maintains only the control plane.
+	scopeControlPlane maintenanceScope = "control plane"
+	// scopeWorkers maintains only the worker pools.
+	scopeWorkers maintenanceScope = "worker pools"
+)
+
+// maintenanceScopeForShoot returns the maintenance scope requested via the maintenance operation annotation. Like
+// `maintain-now`, the scope-restricting values also force an immediate maintenance run.
+func maintenanceScopeForShoot(shoot *gardencorev1beta1.Shoot) maintenanceScope {
+	switch shoot.Annotations[v1beta1constants.GardenerMaintenanceOperation] {
+	case v1beta1constants.ShootOperationMaintainControlPlane:
+		return scopeControlPlane
+	case v1beta1constants.ShootOperationMaintainWorkers:
+		return scopeWorkers
+	}
+	return scopeFull
+}
+
+func (s maintenanceScope) includesControlPlane() bool {
+	return s != scopeWorkers
+}
+
+func (s maintenanceScope) includesWorkers() bool {
+	return s != scopeControlPlane
+}
+
+// scheduleMatchesToday returns whether the optional cron schedule of the shoot's maintenance section matches a point
+// in time on the current day (in UTC). If no schedule is configured, the daily time window is always eligible.
+func scheduleMatchesToday(shoot *gardencorev1beta1.Shoot, clock clock.Clock) bool {
+	if shoot.Spec.Maintenance == nil || shoot.Spec.Maintenance.Schedule == nil {
+		return true
+	}
+
+	schedule, err := cron.ParseStandard(*shoot.Spec.Maintenance.Schedule)
+	if err != nil {
+		// invalid schedules are rejected by the Shoot validation, in doubt do not block maintenance
+		return true
+	}
+
+	startOfDay := clock.Now().UTC().Truncate(24 * time.Hour)
+	return schedule.Next(startOfDay.Add(-time.Second)).Before(startOfDay.Add(24 * time.Hour))
 }
 
 func hasMaintainNowAnnotation(shoot *gardencorev1beta1.Shoot) bool {
@@ -579,6 +1597,12 @@ func hasMaintainNowAnnotation(shoot *gardencorev1beta1.Shoot) bool {
 	return ok && operation == v1beta1constants.ShootOperationMaintain
 }
 
+// hasForcedMaintainNowAnnotation returns whether an immediate maintenance run outside the maintenance time window was
+// requested via the `maintenance.gardener.cloud/operation=maintain-now` annotation.
+func hasForcedMaintainNowAnnotation(shoot *gardencorev1beta1.Shoot) bool {
+	return shoot.Annotations[v1beta1constants.GardenerMaintenanceOperation] == v1beta1constants.ShootOperationMaintainNow
+}
+
 func needsRetry(shoot *gardencorev1beta1.Shoot) bool {
 	needsRetryOperation := false
 
@@ -589,19 +1613,52 @@ func needsRetry(shoot *gardencorev1beta1.Shoot) bool {
 	return needsRetryOperation
 }
 
+// allowedMaintenanceOperations is the set of operation values which may be promoted from the
+// `maintenance.gardener.cloud/operation` annotation to the `gardener.cloud/operation` annotation during maintenance.
+// It contains the credentials rotation operations next to the plain reconcile/retry operations.
+var allowedMaintenanceOperations = sets.New(
+	v1beta1constants.GardenerOperationReconcile,
+	v1beta1constants.ShootOperationRetry,
+	v1beta1constants.ShootOperationRotateKubeconfigCredentials,
+	v1beta1constants.ShootOperationRotateSSHKeypair,
+	v1beta1constants.OperationRotateCredentialsStart,
+	v1beta1constants.OperationRotateCredentialsComplete,
+	v1beta1constants.OperationRotateCAStart,
+	v1beta1constants.OperationRotateCAComplete,
+	v1beta1constants.OperationRotateObservabilityCredentials,
+	v1beta1constants.OperationRotateServiceAccountKeyStart,
+	v1beta1constants.OperationRotateServiceAccountKeyComplete,
+	v1beta1constants.OperationRotateETCDEncryptionKeyStart,
+	v1beta1constants.OperationRotateETCDEncryptionKeyComplete,
+)
+
 func getOperation(shoot *gardencorev1beta1.Shoot) string {
 	var (
 		operation            = v1beta1constants.GardenerOperationReconcile
 		maintenanceOperation = shoot.Annotations[v1beta1constants.GardenerMaintenanceOperation]
 	)
 
-	if maintenanceOperation != "" {
-		operation = maintenanceOperation
+	// the `maintain-now` value and the scope-restricting values only control the maintenance run itself, they are no
+	// operations to be applied to the Shoot
+	switch maintenanceOperation {
+	case "", v1beta1constants.ShootOperationMaintainNow, v1beta1constants.ShootOperationMaintainControlPlane, v1beta1constants.ShootOperationMaintainWorkers:
+	default:
+		// only promote operations from the known set, everything else is dropped in favor of a plain reconciliation
+		if allowedMaintenanceOperations.Has(maintenanceOperation) {
+			operation = maintenanceOperation
+		}
 	}
 
 	return operation
 }
 
+// ResolveImageForCRI returns a copy of the given machine image containing only the versions which are eligible for
+// the given target architecture and CRI configuration. An unset CRI configuration defaults to docker, matching the
+// defaulting of the worker pool CRI.
+func ResolveImageForCRI(machineImageFromCloudProfile *gardencorev1beta1.MachineImage, arch *string, workerCRI *gardencorev1beta1.CRI) *gardencorev1beta1.MachineImage {
+	return filterForCRI(filterForArchitecture(machineImageFromCloudProfile, arch), workerCRI)
+}
+
 func filterForArchitecture(machineImageFromCloudProfile *gardencorev1beta1.MachineImage, arch *string) *gardencorev1beta1.MachineImage {
 	filteredMachineImages := gardencorev1beta1.MachineImage{
 		Name:           machineImageFromCloudProfile.Name,
@@ -667,6 +1724,101 @@ func filterForKubeleteVersionConstraint(machineImageFromCloudProfile *gardencore
 	return &filteredMachineImages
 }
 
+// availableUpdates enumerates, per target, the latest version eligible under the configured update strategy which is
+// newer than the current one but was not applied because auto-update is disabled for the target. It reuses the version
+// resolvers without applying their result and is best-effort: targets whose resolution fails are left out.
+func availableUpdates(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) []gardencorev1beta1.AvailableUpdate {
+	var updates []gardencorev1beta1.AvailableUpdate
+
+	if !shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion {
+		if version, err := determineKubernetesVersion(shoot.Spec.Kubernetes.Version, cloudProfile, false); err == nil && version != "" {
+			updates = append(updates, gardencorev1beta1.AvailableUpdate{Target: "Control Plane", Version: version})
+		}
+	}
+
+	if v1beta1helper.IsWorkerless(shoot) {
+		return updates
+	}
+
+	controlPlaneVersion, err := semver.NewVersion(shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return updates
+	}
+
+	imageNamesForAutoUpdate := machineImageNamesForAutoUpdate(shoot)
+
+	for _, worker := range shoot.Spec.Provider.Workers {
+		workerImage := worker.Machine.Image
+		if workerImage == nil || workerImage.Version == nil {
+			continue
+		}
+
+		autoUpdate := pointer.BoolDeref(shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion, false)
+		if imageNamesForAutoUpdate != nil && !imageNamesForAutoUpdate.Has(workerImage.Name) {
+			autoUpdate = false
+		}
+		if autoUpdate {
+			continue
+		}
+
+		machineImageFromCloudProfile, err := determineMachineImage(cloudProfile, workerImage)
+		if err != nil {
+			continue
+		}
+		if workerImage.UpdateStrategy != nil {
+			machineImageFromCloudProfile.UpdateStrategy = workerImage.UpdateStrategy
+		}
+
+		kubeletVersion, err := v1beta1helper.CalculateEffectiveKubernetesVersion(controlPlaneVersion, worker.Kubernetes)
+		if err != nil {
+			continue
+		}
+
+		filteredVersions := filterForKubeleteVersionConstraint(ResolveImageForCRI(&machineImageFromCloudProfile, worker.Machine.Architecture, worker.CRI), kubeletVersion)
+		if workerImage.MaximumVersion != nil {
+			if filteredVersions, err = filterForMaximumVersion(filteredVersions, *workerImage.MaximumVersion); err != nil {
+				continue
+			}
+		}
+
+		if version, err := determineMachineImageVersion(workerImage, filteredVersions, false); err == nil && version != "" {
+			updates = append(updates, gardencorev1beta1.AvailableUpdate{Target: fmt.Sprintf("Worker pool %q", worker.Name), Version: version})
+		}
+	}
+
+	return updates
+}
+
+// filterForMaximumVersion removes all versions higher than the given maximum version. It bounds automatic updates to
+// the per-worker ceiling configured on the machine image.
+func filterForMaximumVersion(machineImageFromCloudProfile *gardencorev1beta1.MachineImage, maximumVersion string) (*gardencorev1beta1.MachineImage, error) {
+	filteredMachineImages := gardencorev1beta1.MachineImage{
+		Name:           machineImageFromCloudProfile.Name,
+		UpdateStrategy: machineImageFromCloudProfile.UpdateStrategy,
+		Versions:       []gardencorev1beta1.MachineImageVersion{},
+	}
+
+	for _, cloudProfileVersion := range machineImageFromCloudProfile.Versions {
+		exceedsMaximum, err := versionutils.CompareVersions(cloudProfileVersion.Version, ">", maximumVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare version %q with the configured maximum version %q: %w", cloudProfileVersion.Version, maximumVersion, err)
+		}
+		if exceedsMaximum {
+			continue
+		}
+
+		filteredMachineImages.Versions = append(filteredMachineImages.Versions, cloudProfileVersion)
+	}
+
+	return &filteredMachineImages, nil
+}
+
+// usesDockerCRI reports whether the given worker CRI configuration effectively uses docker. An unset configuration
+// defaults to docker, matching the filtering in filterForCRI.
+func usesDockerCRI(workerCRI *gardencorev1beta1.CRI) bool {
+	return workerCRI == nil || workerCRI.Name == gardencorev1beta1.CRINameDocker
+}
+
 func findCRIByName(wanted gardencorev1beta1.CRIName, cris []gardencorev1beta1.CRI) (gardencorev1beta1.CRI, bool) {
 	for _, cri := range cris {
 		if cri.Name == wanted {
@@ -709,20 +1861,16 @@ func determineMachineImage(cloudProfile *gardencorev1beta1.CloudProfile, shootMa
 func shouldMachineImageVersionBeUpdated(shootMachineImage *gardencorev1beta1.ShootMachineImage, machineImage *gardencorev1beta1.MachineImage, autoUpdate bool) (shouldBeUpdated bool, reason string, isExpired bool) {
 	versionExistsInCloudProfile, versionIndex := v1beta1helper.ShootMachineImageVersionExists(*machineImage, *shootMachineImage)
 
-	var updateReason string
 	if !versionExistsInCloudProfile {
-		updateReason = "Version does not exist in CloudProfile"
-		return true, updateReason, true
+		return true, ReasonVersionMissingInCloudProfile.String(), true
 	}
 
 	if ExpirationDateExpired(machineImage.Versions[versionIndex].ExpirationDate) {
-		updateReason = fmt.Sprintf("Machine image version expired - force update required (image update strategy: %s)", *machineImage.UpdateStrategy)
-		return true, updateReason, true
+		return true, ReasonMachineImageVersionExpired.WithUpdateStrategy(*machineImage.UpdateStrategy), true
 	}
 
 	if autoUpdate {
-		updateReason = fmt.Sprintf("Automatic update of the machine image version is configured (image update strategy: %s)", *machineImage.UpdateStrategy)
-		return true, updateReason, false
+		return true, ReasonMachineImageAutoUpdate.WithUpdateStrategy(*machineImage.UpdateStrategy), false
 	}
 
 	return false, "", false
@@ -748,7 +1896,7 @@ func determineMachineImageVersion(shootMachineImage *gardencorev1beta1.ShootMach
 		getHigherVersionAutoUpdate = v1beta1helper.GetOverallLatestVersionForAutoUpdate
 		// cannot force update the overall latest version if it is expired
 		getHigherVersionForceUpdate = func(versions []gardencorev1beta1.ExpirableVersion, currentVersion string) (bool, string, error) {
-			return false, "", fmt.Errorf("either the machine image %q is reaching end of life and migration to another machine image is required or there is a misconfiguration in the CloudProfile. If it is the latter, make sure the machine image in the CloudProfile has at least one version that is not expired, not in preview and greater or equal to the current Shoot image version %q", shootMachineImage.Name, *shootMachineImage.Version)
+			return false, "", fmt.Errorf("either the machine image %q is reaching end of life and migration to another machine image is required or there is a misconfiguration in the CloudProfile. If it is the latter, make sure the machine image in the CloudProfile has at least one version that is not expired, not in preview and greater or equal to the current Shoot image version %q: %w", shootMachineImage.Name, *shootMachineImage.Version, ErrNoHigherVersionAvailable)
 		}
 	}
 
@@ -795,12 +1943,30 @@ func determineVersionForStrategy(expirableVersions []gardencorev1beta1.Expirable
 	//  - Auto update strategy "minor": no higher next major version available (e.g. shoot is on 576.3.0, but there is no higher major version available)
 	//  - Auto update strategy "major": already on latest overall version, but the latest version is expired. EOL for image or CloudProfile misconfiguration.
 	if !forceUpdateVersionAvailable {
-		return "", fmt.Errorf("cannot perform forceful update of expired version %q. No suitable version found in CloudProfile - this is most likely a misconfiguration of the CloudProfile", currentVersion)
+		return "", fmt.Errorf("cannot perform forceful update of expired version %q. No suitable version found in CloudProfile - this is most likely a misconfiguration of the CloudProfile: %w", currentVersion, ErrNoHigherVersionAvailable)
 	}
 
 	return versionForForceUpdate, nil
 }
 
+// startChange returns a MaintenanceChange for the given target with the start time set to the current time.
+func (r *Reconciler) startChange(target string) gardencorev1beta1.MaintenanceChange {
+	return gardencorev1beta1.MaintenanceChange{Target: target, StartTime: &metav1.Time{Time: r.Clock.Now()}}
+}
+
+// finishChange sets the end time of the given change to the current time and returns it.
+func (r *Reconciler) finishChange(change gardencorev1beta1.MaintenanceChange) gardencorev1beta1.MaintenanceChange {
+	change.EndTime = &metav1.Time{Time: r.Clock.Now()}
+	return change
+}
+
+// noHigherVersionDescription returns the uniform maintenance failure description reported when no version higher than
+// <currentVersion> exists. The Kubernetes and the machine image flow deliberately share this wording so that consumers
+// of the maintenance status can match on a single reason.
+func noHigherVersionDescription(currentVersion string) string {
+	return fmt.Sprintf("%s: no version higher than %q exists within the bounds of the update strategy", ReasonNoHigherVersionAvailable, currentVersion)
+}
+
 // ExpirationDateExpired returns if now is equal or after the given expirationDate
 func ExpirationDateExpired(timestamp *metav1.Time) bool {
 	if timestamp == nil {
@@ -856,3 +2022,108 @@ func ensureSufficientMaxWorkers(shoot *gardencorev1beta1.Shoot, reason string) [
 
 	return reasonsForUpdate
 }
+
+// workerPoolKubeletMaintainers is the allow-list of version-specific kubelet settings which are adjusted when
+// a worker pool's Kubernetes version is force-updated across a minor boundary. Each entry inspects the pool's
+// kubelet configuration, clears settings that are invalid for the new version and returns a reason for every
+// change it makes. Settings not covered here are deliberately left untouched - silently rewriting arbitrary
+// user configuration is riskier than letting the dry-run update surface a validation error.
+var workerPoolKubeletMaintainers = []func(pool *gardencorev1beta1.Worker, version *semver.Version) []string{
+	clearUnsupportedKubeletFeatureGates,
+}
+
+// maintainWorkerPoolKubeletSettings clears version-specific kubelet settings of the given worker pool which
+// are no longer valid for the given (new) Kubernetes version. It returns a reason for every cleared setting.
+func maintainWorkerPoolKubeletSettings(pool *gardencorev1beta1.Worker, version *semver.Version) []string {
+	if pool.Kubernetes == nil || pool.Kubernetes.Kubelet == nil {
+		return nil
+	}
+
+	var reasonsForUpdate []string
+	for _, maintainSetting := range workerPoolKubeletMaintainers {
+		reasonsForUpdate = append(reasonsForUpdate, maintainSetting(pool, version)...)
+	}
+	return reasonsForUpdate
+}
+
+// clearUnsupportedKubeletFeatureGates removes feature gates from the pool's kubelet configuration which are
+// not supported by the given Kubernetes version anymore (e.g. because they were removed in the new minor).
+func clearUnsupportedKubeletFeatureGates(pool *gardencorev1beta1.Worker, version *semver.Version) []string {
+	var reasonsForUpdate []string
+
+	for _, featureGate := range sets.List(sets.KeySet(pool.Kubernetes.Kubelet.FeatureGates)) {
+		supported, err := featuresvalidation.IsFeatureGateSupported(featureGate, version.String())
+		if err != nil || supported {
+			// unknown feature gates are left to the validation, only documented removals are settled here
+			continue
+		}
+		delete(pool.Kubernetes.Kubelet.FeatureGates, featureGate)
+		reasonsForUpdate = append(reasonsForUpdate, fmt.Sprintf("Removed kubelet feature gate %q of worker pool %q. Reason: it is no longer supported in Kubernetes version %q", featureGate, pool.Name, version.String()))
+	}
+
+	return reasonsForUpdate
+}
+
+// featureGateMigration describes a feature gate which was renamed or removed in a specific Kubernetes minor version.
+type featureGateMigration struct {
+	// name is the name of the feature gate which is no longer accepted.
+	name string
+	// replacement is the new name of the feature gate. If empty, the gate was removed without replacement.
+	replacement string
+	// removedInMinor is the first Kubernetes version which no longer accepts the old name.
+	removedInMinor *semver.Version
+}
+
+// featureGateMigrations lists the feature gate renames and removals which are settled during maintenance when a
+// version update crosses the respective minor. Without the rewrite, Shoots carrying the old gate name would fail the
+// feature gate validation after a force update.
+var featureGateMigrations = []featureGateMigration{
+	{name: "DynamicKubeletConfig", removedInMinor: semver.MustParse("1.26")},
+	{name: "UserNamespacesStatelessPodsSupport", replacement: "UserNamespacesSupport", removedInMinor: semver.MustParse("1.28")},
+}
+
+// migrateFeatureGates rewrites feature gates in the Shoot's Kubernetes section which were renamed or removed in the
+// new Kubernetes version. It returns a reason for every rewritten gate.
+func migrateFeatureGates(shoot *gardencorev1beta1.Shoot, oldVersion, newVersion *semver.Version) []string {
+	var reasons []string
+
+	migrate := func(featureGates map[string]bool, component string) {
+		for _, migration := range featureGateMigrations {
+			if !oldVersion.LessThan(migration.removedInMinor) || newVersion.LessThan(migration.removedInMinor) {
+				continue
+			}
+
+			value, ok := featureGates[migration.name]
+			if !ok {
+				continue
+			}
+
+			delete(featureGates, migration.name)
+			if migration.replacement != "" {
+				featureGates[migration.replacement] = value
+				reasons = append(reasons, fmt.Sprintf("Renamed feature gate %q of %s to %q. Reason: the old name is no longer accepted in Kubernetes version %q", migration.name, component, migration.replacement, newVersion.String()))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("Removed feature gate %q of %s. Reason: it was removed in Kubernetes version %q", migration.name, component, newVersion.String()))
+			}
+		}
+	}
+
+	kubernetes := &shoot.Spec.Kubernetes
+	if kubernetes.KubeAPIServer != nil {
+		migrate(kubernetes.KubeAPIServer.FeatureGates, "kube-apiserver")
+	}
+	if kubernetes.KubeControllerManager != nil {
+		migrate(kubernetes.KubeControllerManager.FeatureGates, "kube-controller-manager")
+	}
+	if kubernetes.KubeScheduler != nil {
+		migrate(kubernetes.KubeScheduler.FeatureGates, "kube-scheduler")
+	}
+	if kubernetes.KubeProxy != nil {
+		migrate(kubernetes.KubeProxy.FeatureGates, "kube-proxy")
+	}
+	if kubernetes.Kubelet != nil {
+		migrate(kubernetes.Kubelet.FeatureGates, "kubelet")
+	}
+
+	return reasons
+}
@@ -15,33 +15,1565 @@
 package maintenance
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/controllermanager/apis/config"
+	. "github.com/gardener/gardener/pkg/utils/test/matchers"
 )
 
+// denyMajorJumpsPolicyDecider denies every update increasing the major version.
+type denyMajorJumpsPolicyDecider struct{}
+
+func (denyMajorJumpsPolicyDecider) Decide(_ context.Context, _ *gardencorev1beta1.Shoot, _, fromVersion, toVersion string) PolicyDecision {
+	if semver.MustParse(toVersion).Major() > semver.MustParse(fromVersion).Major() {
+		return PolicyDecision{Reason: "major version jumps are not allowed"}
+	}
+	return PolicyDecision{Allowed: true}
+}
+
 var _ = Describe("Shoot Maintenance", func() {
 	var (
 		log logr.Logger
 		now time.Time
 
-		expirationDateInTheFuture, expirationDateInThePast metav1.Time
-	)
+		expirationDateInTheFuture, expirationDateInThePast metav1.Time
+	)
+
+	BeforeEach(func() {
+		log = logr.Discard()
+		now = time.Now()
+		expirationDateInTheFuture = metav1.Time{Time: now.Add(time.Minute * 10)}
+		expirationDateInThePast = metav1.Time{Time: now.AddDate(0, 0, -1)}
+	})
+
+	Context("Shoot Maintenance", func() {
+		Describe("#scheduleMatchesToday", func() {
+			var (
+				fakeClock *testclock.FakeClock
+				shoot     *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				// Wednesday, 3 May 2023
+				fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+				shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{Maintenance: &gardencorev1beta1.Maintenance{}}}
+			})
+
+			It("should match when no schedule is configured", func() {
+				Expect(scheduleMatchesToday(shoot, fakeClock)).To(BeTrue())
+			})
+
+			It("should match when the schedule includes today", func() {
+				shoot.Spec.Maintenance.Schedule = pointer.String("0 0 * * 3")
+				Expect(scheduleMatchesToday(shoot, fakeClock)).To(BeTrue())
+			})
+
+			It("should not match when the schedule excludes today", func() {
+				shoot.Spec.Maintenance.Schedule = pointer.String("0 0 * * 0")
+				Expect(scheduleMatchesToday(shoot, fakeClock)).To(BeFalse())
+			})
+		})
+
+		Describe("#mustMaintainNow", func() {
+			var (
+				fakeClock *testclock.FakeClock
+				shoot     *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				// outside of the configured maintenance time window
+				fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+				shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+					Maintenance: &gardencorev1beta1.Maintenance{
+						TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+					},
+				}}
+			})
+
+			It("should not maintain outside of the time window", func() {
+				Expect(mustMaintainNow(shoot, fakeClock)).To(BeFalse())
+			})
+
+			It("should maintain outside of the time window when forced via the maintain-now operation", func() {
+				shoot.Annotations = map[string]string{"maintenance.gardener.cloud/operation": "maintain-now"}
+				Expect(mustMaintainNow(shoot, fakeClock)).To(BeTrue())
+			})
+
+			It("should maintain outside of the time window when triggered via the generic maintain operation", func() {
+				shoot.Annotations = map[string]string{"gardener.cloud/operation": "maintain"}
+				Expect(mustMaintainNow(shoot, fakeClock)).To(BeTrue())
+			})
+
+			It("should maintain outside of the time window when a scope-restricted run is requested", func() {
+				shoot.Annotations = map[string]string{"maintenance.gardener.cloud/operation": "maintain-workers"}
+				Expect(mustMaintainNow(shoot, fakeClock)).To(BeTrue())
+			})
+		})
+
+		Describe("#shootWithEffectiveTimeWindow", func() {
+			var (
+				fakeClock  *testclock.FakeClock
+				reconciler *Reconciler
+				shoot      *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+				reconciler = &Reconciler{
+					Clock: fakeClock,
+					Config: config.ShootMaintenanceControllerConfiguration{
+						DefaultTimeWindow: &config.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+					},
+				}
+				shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+					Maintenance: &gardencorev1beta1.Maintenance{},
+				}}
+			})
+
+			It("should apply the default time window to a window-less Shoot", func() {
+				shootWithWindow := reconciler.shootWithEffectiveTimeWindow(shoot)
+
+				Expect(shootWithWindow.Spec.Maintenance.TimeWindow).NotTo(BeNil())
+				Expect(shootWithWindow.Spec.Maintenance.TimeWindow.End).To(Equal("230000+0000"))
+				// the persisted Shoot must not be modified
+				Expect(shoot.Spec.Maintenance.TimeWindow).To(BeNil())
+				// the window-less Shoot must no longer be maintainable at any time
+				Expect(mustMaintainNow(shootWithWindow, fakeClock)).To(BeFalse())
+			})
+
+			It("should jitter the default window begin deterministically per Shoot", func() {
+				reconciler.Config.DefaultTimeWindow = &config.MaintenanceTimeWindow{Begin: "000000+0000", End: "230000+0000"}
+
+				shoot.ObjectMeta = metav1.ObjectMeta{Name: "first", Namespace: "garden-foo"}
+				otherShoot := shoot.DeepCopy()
+				otherShoot.Name = "second"
+
+				firstWindow := reconciler.shootWithEffectiveTimeWindow(shoot).Spec.Maintenance.TimeWindow
+				secondWindow := reconciler.shootWithEffectiveTimeWindow(otherShoot).Spec.Maintenance.TimeWindow
+
+				// the two window-less Shoots are spread to different trigger times within the default window
+				Expect(firstWindow.Begin).NotTo(Equal(secondWindow.Begin))
+				// the jitter is deterministic, i.e. repeated computations yield the same window
+				Expect(reconciler.shootWithEffectiveTimeWindow(shoot).Spec.Maintenance.TimeWindow.Begin).To(Equal(firstWindow.Begin))
+			})
+
+			It("should not touch a Shoot which declares an own time window", func() {
+				shoot.Spec.Maintenance.TimeWindow = &gardencorev1beta1.MaintenanceTimeWindow{Begin: "100000+0000", End: "110000+0000"}
+
+				Expect(reconciler.shootWithEffectiveTimeWindow(shoot)).To(BeIdenticalTo(shoot))
+			})
+
+			It("should return the Shoot unchanged when no default time window is configured", func() {
+				reconciler.Config.DefaultTimeWindow = nil
+
+				Expect(reconciler.shootWithEffectiveTimeWindow(shoot)).To(BeIdenticalTo(shoot))
+				Expect(mustMaintainNow(shoot, fakeClock)).To(BeTrue())
+			})
+		})
+
+		Describe("#applyMaintenancePolicy", func() {
+			var (
+				policy *gardencorev1beta1.MaintenancePolicy
+				shoot  *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				policy = &gardencorev1beta1.MaintenancePolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "default-policy"},
+					Spec: gardencorev1beta1.MaintenancePolicySpec{
+						TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+						AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true},
+					},
+				}
+				shoot = &gardencorev1beta1.Shoot{}
+			})
+
+			It("should return the Shoot unchanged when it does not reference a policy", func() {
+				Expect(applyMaintenancePolicy(shoot, nil)).To(BeIdenticalTo(shoot))
+			})
+
+			It("should inherit the time window and auto-update settings of the policy", func() {
+				shootWithPolicy := applyMaintenancePolicy(shoot, policy)
+
+				Expect(shootWithPolicy.Spec.Maintenance.TimeWindow).To(Equal(policy.Spec.TimeWindow))
+				Expect(shootWithPolicy.Spec.Maintenance.AutoUpdate).To(Equal(policy.Spec.AutoUpdate))
+				// the persisted Shoot must not be modified
+				Expect(shoot.Spec.Maintenance).To(BeNil())
+			})
+
+			It("should prefer the settings configured on the Shoot itself", func() {
+				shoot.Spec.Maintenance = &gardencorev1beta1.Maintenance{
+					TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "100000+0000", End: "110000+0000"},
+					AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: false},
+				}
+
+				shootWithPolicy := applyMaintenancePolicy(shoot, policy)
+
+				Expect(shootWithPolicy.Spec.Maintenance).To(Equal(shoot.Spec.Maintenance))
+			})
+		})
+
+		Describe("#requeueAfterDuration", func() {
+			var (
+				fakeClock *testclock.FakeClock
+				shoot     *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+				shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+					Maintenance: &gardencorev1beta1.Maintenance{
+						TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+					},
+				}}
+			})
+
+			It("should requeue exactly at the next window start", func() {
+				duration, nextMaintenance := requeueAfterDuration(shoot, fakeClock)
+
+				Expect(duration).To(Equal(8 * time.Hour))
+				Expect(nextMaintenance).To(Equal(time.Date(2023, 5, 3, 22, 0, 0, 0, time.UTC)))
+			})
+
+			It("should requeue at the window start on the following day when the window has already begun", func() {
+				fakeClock.SetTime(time.Date(2023, 5, 3, 22, 0, 0, 0, time.UTC))
+
+				duration, nextMaintenance := requeueAfterDuration(shoot, fakeClock)
+
+				Expect(duration).To(Equal(24 * time.Hour))
+				Expect(nextMaintenance).To(Equal(time.Date(2023, 5, 4, 22, 0, 0, 0, time.UTC)))
+			})
+		})
+
+		Describe("#updateNextWindowAnnotations", func() {
+			var (
+				ctx        context.Context
+				fakeClient client.Client
+				reconciler *Reconciler
+				shoot      *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				shoot = &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+					Spec: gardencorev1beta1.ShootSpec{
+						Maintenance: &gardencorev1beta1.Maintenance{
+							TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+						},
+					},
+				}
+				fakeClient = fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot).
+					Build()
+				reconciler = &Reconciler{Client: fakeClient}
+			})
+
+			It("should announce the next maintenance window via annotations", func() {
+				nextMaintenance := time.Date(2023, 5, 3, 22, 17, 0, 0, time.UTC)
+
+				Expect(reconciler.updateNextWindowAnnotations(ctx, shoot, nextMaintenance)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Annotations).To(HaveKeyWithValue(AnnotationNextMaintenanceWindowStart, "2023-05-03T22:17:00Z"))
+				Expect(persistedShoot.Annotations).To(HaveKeyWithValue(AnnotationNextMaintenanceWindowEnd, "2023-05-03T22:45:00Z"))
+			})
+
+			It("should update the annotations after the time window was edited", func() {
+				Expect(reconciler.updateNextWindowAnnotations(ctx, shoot, time.Date(2023, 5, 3, 22, 17, 0, 0, time.UTC))).To(Succeed())
+
+				shoot.Spec.Maintenance.TimeWindow = &gardencorev1beta1.MaintenanceTimeWindow{Begin: "100000+0000", End: "110000+0000"}
+				Expect(reconciler.updateNextWindowAnnotations(ctx, shoot, time.Date(2023, 5, 4, 10, 33, 0, 0, time.UTC))).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Annotations).To(HaveKeyWithValue(AnnotationNextMaintenanceWindowStart, "2023-05-04T10:33:00Z"))
+				Expect(persistedShoot.Annotations).To(HaveKeyWithValue(AnnotationNextMaintenanceWindowEnd, "2023-05-04T10:45:00Z"))
+			})
+		})
+
+		Describe("SeedLimiter", func() {
+			It("should only allow the configured number of concurrent maintenance operations per seed", func() {
+				limiter := NewSeedLimiter(1)
+
+				Expect(limiter.TryAcquire("seed")).To(BeTrue())
+				Expect(limiter.TryAcquire("seed")).To(BeFalse())
+				Expect(limiter.TryAcquire("other-seed")).To(BeTrue())
+
+				limiter.Release("seed")
+				Expect(limiter.TryAcquire("seed")).To(BeTrue())
+			})
+
+			It("should delay the maintenance of a second Shoot on the same seed", func() {
+				var (
+					ctx       = context.Background()
+					fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					limiter   = NewSeedLimiter(1)
+					shoot     = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "shoot",
+							Namespace:   "garden-foo",
+							Annotations: map[string]string{"gardener.cloud/operation": "maintain"},
+						},
+						Spec: gardencorev1beta1.ShootSpec{
+							SeedName: pointer.String("seed"),
+							Maintenance: &gardencorev1beta1.Maintenance{
+								TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "220000+0000", End: "230000+0000"},
+							},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, SeedLimiter: limiter}
+
+				// another Shoot on the same seed is already in maintenance
+				Expect(limiter.TryAcquire("seed")).To(BeTrue())
+
+				result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(shoot)})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Status.LastMaintenance).To(BeNil())
+			})
+		})
+
+		Describe("#applyPolicyDecisions", func() {
+			var (
+				ctx             context.Context
+				reconciler      *Reconciler
+				shoot           *gardencorev1beta1.Shoot
+				maintainedShoot *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				reconciler = &Reconciler{
+					Config:        config.ShootMaintenanceControllerConfiguration{FeatureGates: map[string]bool{FeaturePolicyDecisions: true}},
+					PolicyDecider: denyMajorJumpsPolicyDecider{},
+				}
+				shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+					Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+					Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+						Name:    "cpu-worker",
+						Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "CoreOs", Version: pointer.String("1.0.0")}},
+					}}},
+				}}
+				maintainedShoot = shoot.DeepCopy()
+			})
+
+			It("should revert updates denied by the decider", func() {
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("2.0.0")
+				workerToMachineImageUpdate := map[string]updateResult{
+					"cpu-worker": {isSuccessful: true, fromVersion: "1.0.0", toVersion: "2.0.0"},
+				}
+
+				_, notes := reconciler.applyPolicyDecisions(ctx, shoot, maintainedShoot, nil, nil, workerToMachineImageUpdate)
+
+				Expect(notes).To(ConsistOf(`Update of "worker-pool/cpu-worker/machine-image" from "1.0.0" to "2.0.0" was denied by policy: major version jumps are not allowed`))
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("1.0.0")))
+				Expect(workerToMachineImageUpdate).To(BeEmpty())
+			})
+
+			It("should keep updates allowed by the decider", func() {
+				maintainedShoot.Spec.Kubernetes.Version = "1.27.1"
+				kubernetesControlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.0", toVersion: "1.27.1"}
+
+				controlPlaneUpdate, notes := reconciler.applyPolicyDecisions(ctx, shoot, maintainedShoot, kubernetesControlPlaneUpdate, nil, nil)
+
+				Expect(notes).To(BeEmpty())
+				Expect(controlPlaneUpdate).To(Equal(kubernetesControlPlaneUpdate))
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.27.1"))
+			})
+
+			It("should not consult a decider for failed updates", func() {
+				controlPlaneUpdate, notes := reconciler.applyPolicyDecisions(ctx, shoot, maintainedShoot, &updateResult{isSuccessful: false, fromVersion: "1.26.0", toVersion: "2.0.0"}, nil, nil)
+
+				Expect(notes).To(BeEmpty())
+				Expect(controlPlaneUpdate).NotTo(BeNil())
+			})
+
+			It("should not consult the decider when the PolicyDecisions feature gate is disabled", func() {
+				reconciler.Config.FeatureGates[FeaturePolicyDecisions] = false
+
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("2.0.0")
+				workerToMachineImageUpdate := map[string]updateResult{
+					"cpu-worker": {isSuccessful: true, fromVersion: "1.0.0", toVersion: "2.0.0"},
+				}
+
+				_, notes := reconciler.applyPolicyDecisions(ctx, shoot, maintainedShoot, nil, nil, workerToMachineImageUpdate)
+
+				Expect(notes).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("2.0.0")))
+				Expect(workerToMachineImageUpdate).To(HaveKey("cpu-worker"))
+			})
+		})
+
+		Describe("#deferNonCanaryUpdates", func() {
+			var (
+				shoot           *gardencorev1beta1.Shoot
+				maintainedShoot *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				shoot = &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{
+						{
+							Name:        "canary-worker",
+							Machine:     gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "CoreOs", Version: pointer.String("1.0.0")}},
+							Maintenance: &gardencorev1beta1.WorkerMaintenance{Canary: true},
+						},
+						{
+							Name:    "cpu-worker",
+							Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "CoreOs", Version: pointer.String("1.0.0")}},
+						},
+					}},
+				}}
+				maintainedShoot = shoot.DeepCopy()
+			})
+
+			It("should defer the non-canary pool update to the next maintenance window", func() {
+				// first maintenance window: both pools received an update
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version = pointer.String("1.1.0")
+				workerToMachineImageUpdate := map[string]updateResult{
+					"canary-worker": {isSuccessful: true},
+					"cpu-worker":    {isSuccessful: true},
+				}
+
+				Expect(deferNonCanaryUpdates(shoot, maintainedShoot, nil, workerToMachineImageUpdate)).To(ConsistOf("cpu-worker"))
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("1.1.0")))
+				Expect(maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version).To(PointTo(Equal("1.0.0")))
+				Expect(workerToMachineImageUpdate).NotTo(HaveKey("cpu-worker"))
+
+				// subsequent maintenance window: the canary pool is already up-to-date
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				maintainedShoot = shoot.DeepCopy()
+				maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version = pointer.String("1.1.0")
+				workerToMachineImageUpdate = map[string]updateResult{"cpu-worker": {isSuccessful: true}}
+
+				Expect(deferNonCanaryUpdates(shoot, maintainedShoot, nil, workerToMachineImageUpdate)).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version).To(PointTo(Equal("1.1.0")))
+				Expect(workerToMachineImageUpdate).To(HaveKey("cpu-worker"))
+			})
+
+			It("should only defer non-canary pool updates while the CanaryUpdates feature gate is enabled", func() {
+				reconciler := &Reconciler{Config: config.ShootMaintenanceControllerConfiguration{FeatureGates: map[string]bool{}}}
+
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version = pointer.String("1.1.0")
+				workerToMachineImageUpdate := map[string]updateResult{
+					"canary-worker": {isSuccessful: true},
+					"cpu-worker":    {isSuccessful: true},
+				}
+
+				Expect(reconciler.deferNonCanaryUpdates(shoot, maintainedShoot, nil, workerToMachineImageUpdate)).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version).To(PointTo(Equal("1.1.0")))
+
+				reconciler.Config.FeatureGates[FeatureCanaryUpdates] = true
+
+				Expect(reconciler.deferNonCanaryUpdates(shoot, maintainedShoot, nil, workerToMachineImageUpdate)).To(ConsistOf("cpu-worker"))
+				Expect(maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version).To(PointTo(Equal("1.0.0")))
+			})
+
+			It("should not defer updates when no canary pool is configured", func() {
+				shoot.Spec.Provider.Workers[0].Maintenance = nil
+				maintainedShoot = shoot.DeepCopy()
+				maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version = pointer.String("1.1.0")
+				workerToMachineImageUpdate := map[string]updateResult{"cpu-worker": {isSuccessful: true}}
+
+				Expect(deferNonCanaryUpdates(shoot, maintainedShoot, nil, workerToMachineImageUpdate)).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Provider.Workers[1].Machine.Image.Version).To(PointTo(Equal("1.1.0")))
+			})
+		})
+
+		Describe("#deferDisruptiveUpdates", func() {
+			var (
+				fakeClock       *testclock.FakeClock
+				reconciler      *Reconciler
+				shoot           *gardencorev1beta1.Shoot
+				maintainedShoot *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+				reconciler = &Reconciler{
+					Clock:  fakeClock,
+					Config: config.ShootMaintenanceControllerConfiguration{MinDisruptiveUpdateInterval: &metav1.Duration{Duration: 48 * time.Hour}},
+				}
+				shoot = &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.26.3"},
+					},
+					Status: gardencorev1beta1.ShootStatus{
+						LastMaintenance: &gardencorev1beta1.LastMaintenance{
+							// the previous disruptive maintenance ran one day ago
+							TriggeredTime: metav1.Time{Time: fakeClock.Now().Add(-24 * time.Hour)},
+						},
+					},
+				}
+				maintainedShoot = shoot.DeepCopy()
+			})
+
+			It("should defer a second disruptive update within the interval", func() {
+				maintainedShoot.Spec.Kubernetes.Version = "1.27.0"
+				controlPlaneUpdate := &updateResult{isSuccessful: true, isForceful: true, fromVersion: "1.26.3", toVersion: "1.27.0"}
+
+				controlPlaneUpdate, notes := reconciler.deferDisruptiveUpdates(shoot, maintainedShoot, controlPlaneUpdate, nil, nil)
+				Expect(controlPlaneUpdate).To(BeNil())
+				Expect(notes).To(ConsistOf(ContainSubstring("within the minimum interval of 48h0m0s between disruptive updates")))
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.26.3"))
+			})
+
+			It("should keep patch-level updates within the interval", func() {
+				maintainedShoot.Spec.Kubernetes.Version = "1.26.4"
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.3", toVersion: "1.26.4"}
+
+				controlPlaneUpdate, notes := reconciler.deferDisruptiveUpdates(shoot, maintainedShoot, controlPlaneUpdate, nil, nil)
+				Expect(controlPlaneUpdate).NotTo(BeNil())
+				Expect(notes).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.26.4"))
+			})
+
+			It("should allow disruptive updates once the interval has passed", func() {
+				fakeClock.Step(24 * time.Hour)
+				maintainedShoot.Spec.Kubernetes.Version = "1.27.0"
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.3", toVersion: "1.27.0"}
+
+				controlPlaneUpdate, notes := reconciler.deferDisruptiveUpdates(shoot, maintainedShoot, controlPlaneUpdate, nil, nil)
+				Expect(controlPlaneUpdate).NotTo(BeNil())
+				Expect(notes).To(BeEmpty())
+			})
+
+			It("should not defer updates when no interval is configured", func() {
+				reconciler.Config.MinDisruptiveUpdateInterval = nil
+				maintainedShoot.Spec.Kubernetes.Version = "1.27.0"
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.3", toVersion: "1.27.0"}
+
+				controlPlaneUpdate, notes := reconciler.deferDisruptiveUpdates(shoot, maintainedShoot, controlPlaneUpdate, nil, nil)
+				Expect(controlPlaneUpdate).NotTo(BeNil())
+				Expect(notes).To(BeEmpty())
+			})
+		})
+
+		Describe("#deferUpdatesDuringFreeze", func() {
+			var (
+				fakeClock       *testclock.FakeClock
+				reconciler      *Reconciler
+				shoot           *gardencorev1beta1.Shoot
+				maintainedShoot *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				fakeClock = testclock.NewFakeClock(time.Date(2023, 6, 30, 14, 0, 0, 0, time.UTC))
+				reconciler = &Reconciler{
+					Clock: fakeClock,
+					Config: config.ShootMaintenanceControllerConfiguration{FreezeWindows: []config.MaintenanceFreezeWindow{{
+						// end-of-quarter change freeze which is currently active
+						Begin:                    metav1.Time{Time: time.Date(2023, 6, 26, 0, 0, 0, 0, time.UTC)},
+						End:                      metav1.Time{Time: time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC)},
+						AllowExpiredForceUpdates: true,
+					}}},
+				}
+				shoot = &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.26.3"},
+						Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+							Name:    "cpu-worker",
+							Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "CoreOs", Version: pointer.String("1.0.0")}},
+						}}},
+					},
+				}
+				maintainedShoot = shoot.DeepCopy()
+			})
+
+			It("should defer auto-updates but keep expired force updates during a freeze window", func() {
+				maintainedShoot.Spec.Kubernetes.Version = "1.26.4"
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.3", toVersion: "1.26.4"}
+				workerToMachineImageUpdate := map[string]updateResult{
+					// the machine image version truly expired, so this force update is explicitly allowed
+					"cpu-worker": {isSuccessful: true, isForceful: true, fromVersion: "1.0.0", toVersion: "1.1.0"},
+				}
+
+				controlPlaneUpdate, notes := reconciler.deferUpdatesDuringFreeze(shoot, maintainedShoot, controlPlaneUpdate, nil, workerToMachineImageUpdate)
+				Expect(controlPlaneUpdate).To(BeNil())
+				Expect(notes).To(ConsistOf(ContainSubstring("a change freeze is active from 2023-06-26T00:00:00Z until 2023-07-03T00:00:00Z")))
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.26.3"))
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("1.1.0")))
+				Expect(workerToMachineImageUpdate).To(HaveKey("cpu-worker"))
+			})
+
+			It("should also defer expired force updates when the freeze window does not allow them", func() {
+				reconciler.Config.FreezeWindows[0].AllowExpiredForceUpdates = false
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				workerToMachineImageUpdate := map[string]updateResult{
+					"cpu-worker": {isSuccessful: true, isForceful: true, fromVersion: "1.0.0", toVersion: "1.1.0"},
+				}
+
+				_, notes := reconciler.deferUpdatesDuringFreeze(shoot, maintainedShoot, nil, nil, workerToMachineImageUpdate)
+				Expect(notes).To(ConsistOf(ContainSubstring(`Deferred update of "worker-pool/cpu-worker/machine-image"`)))
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("1.0.0")))
+				Expect(workerToMachineImageUpdate).NotTo(HaveKey("cpu-worker"))
+			})
+
+			It("should not defer updates outside of the freeze windows", func() {
+				fakeClock.SetTime(time.Date(2023, 7, 3, 0, 0, 0, 0, time.UTC))
+				maintainedShoot.Spec.Kubernetes.Version = "1.26.4"
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.3", toVersion: "1.26.4"}
+
+				controlPlaneUpdate, notes := reconciler.deferUpdatesDuringFreeze(shoot, maintainedShoot, controlPlaneUpdate, nil, nil)
+				Expect(controlPlaneUpdate).NotTo(BeNil())
+				Expect(notes).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.26.4"))
+			})
+		})
+
+		Describe("#deferUpdatesForUnhealthyShoot", func() {
+			var (
+				shoot           *gardencorev1beta1.Shoot
+				maintainedShoot *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				shoot = &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+						Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+							Name:    "cpu-worker",
+							Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "CoreOs", Version: pointer.String("1.0.0")}},
+						}}},
+					},
+					Status: gardencorev1beta1.ShootStatus{Conditions: []gardencorev1beta1.Condition{{
+						Type:   gardencorev1beta1.ShootAPIServerAvailable,
+						Status: gardencorev1beta1.ConditionFalse,
+					}}},
+				}
+				maintainedShoot = shoot.DeepCopy()
+			})
+
+			It("should defer auto-updates but keep expired force updates for an unhealthy Shoot", func() {
+				maintainedShoot.Spec.Kubernetes.Version = "1.26.1"
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.0", toVersion: "1.26.1"}
+				workerToMachineImageUpdate := map[string]updateResult{
+					// the machine image version expired, so this update is forceful and must not be deferred
+					"cpu-worker": {isSuccessful: true, isForceful: true, fromVersion: "1.0.0", toVersion: "1.1.0"},
+				}
+
+				controlPlaneUpdate, notes := deferUpdatesForUnhealthyShoot(shoot, maintainedShoot, controlPlaneUpdate, nil, workerToMachineImageUpdate)
+				Expect(controlPlaneUpdate).To(BeNil())
+				Expect(notes).To(ConsistOf(ContainSubstring(`Deferred update of "control-plane/kubernetes" from "1.26.0" to "1.26.1"`)))
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.26.0"))
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("1.1.0")))
+				Expect(workerToMachineImageUpdate).To(HaveKey("cpu-worker"))
+			})
+
+			It("should not defer updates for a healthy Shoot", func() {
+				shoot.Status.Conditions[0].Status = gardencorev1beta1.ConditionTrue
+				maintainedShoot.Spec.Kubernetes.Version = "1.26.1"
+				controlPlaneUpdate := &updateResult{isSuccessful: true, fromVersion: "1.26.0", toVersion: "1.26.1"}
+
+				controlPlaneUpdate, notes := deferUpdatesForUnhealthyShoot(shoot, maintainedShoot, controlPlaneUpdate, nil, nil)
+				Expect(controlPlaneUpdate).NotTo(BeNil())
+				Expect(notes).To(BeEmpty())
+				Expect(maintainedShoot.Spec.Kubernetes.Version).To(Equal("1.26.1"))
+			})
+
+			It("should defer worker pool auto-updates of an unhealthy Shoot", func() {
+				maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("1.1.0")
+				workerToMachineImageUpdate := map[string]updateResult{
+					"cpu-worker": {isSuccessful: true, fromVersion: "1.0.0", toVersion: "1.1.0"},
+				}
+
+				_, notes := deferUpdatesForUnhealthyShoot(shoot, maintainedShoot, nil, nil, workerToMachineImageUpdate)
+				Expect(notes).To(ConsistOf(ContainSubstring(`Deferred update of "worker-pool/cpu-worker/machine-image"`)))
+				Expect(maintainedShoot.Spec.Provider.Workers[0].Machine.Image.Version).To(PointTo(Equal("1.0.0")))
+				Expect(workerToMachineImageUpdate).NotTo(HaveKey("cpu-worker"))
+			})
+		})
+
+		Describe("#reconcile", func() {
+			It("should report a missing CloudProfile in the maintenance status", func() {
+				var (
+					ctx       = context.Background()
+					fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					shoot     = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Maintenance:      &gardencorev1beta1.Maintenance{},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock}
+
+				err := reconciler.reconcile(ctx, log, shoot)
+				Expect(err).To(BeNotFoundError())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateFailed))
+				Expect(persistedShoot.Status.LastMaintenance.FailureReason).To(PointTo(ContainSubstring(`CloudProfile "profile" referenced by the Shoot does not exist`)))
+			})
+
+			It("should leave the control-plane version unchanged when maintenance is restricted to the worker pools", func() {
+				var (
+					ctx           = context.Background()
+					fakeClock     = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					strategyMajor = gardencorev1beta1.UpdateStrategyMajor
+					cloudProfile  = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0", ExpirationDate: &expirationDateInThePast},
+									{Version: "1.26.1"},
+								},
+							},
+							MachineImages: []gardencorev1beta1.MachineImage{{
+								Name:           "foo-image",
+								UpdateStrategy: &strategyMajor,
+								Versions: []gardencorev1beta1.MachineImageVersion{{
+									ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.1.1"},
+									CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+									Architectures:    []string{"amd64"},
+								}},
+							}},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "shoot",
+							Namespace:   "garden-foo",
+							Annotations: map[string]string{"maintenance.gardener.cloud/operation": "maintain-workers"},
+						},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true, MachineImageVersion: pointer.Bool(false)},
+							},
+							Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+								Name: "worker",
+								Machine: gardencorev1beta1.Machine{
+									Image:        &gardencorev1beta1.ShootMachineImage{Name: "foo-image", Version: pointer.String("1.1.1")},
+									Architecture: pointer.String("amd64"),
+								},
+							}}},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, Recorder: record.NewFakeRecorder(8)}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				// the control-plane version is expired, but must not be touched because only the worker pools were maintained
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.0"))
+				Expect(persistedShoot.Annotations).NotTo(HaveKey("maintenance.gardener.cloud/operation"))
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.Description).To(ContainSubstring(`Maintenance was restricted to the worker pools via the "maintenance.gardener.cloud/operation=maintain-workers" annotation`))
+			})
+
+			It("should record the resourceVersion of the observed CloudProfile", func() {
+				var (
+					ctx          = context.Background()
+					fakeClock    = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true},
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, Recorder: record.NewFakeRecorder(8)}
+
+				persistedCloudProfile := &gardencorev1beta1.CloudProfile{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cloudProfile), persistedCloudProfile)).To(Succeed())
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.1"))
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.CloudProfileResourceVersion).To(PointTo(Equal(persistedCloudProfile.ResourceVersion)))
+			})
+
+			It("should emit a skip event when auto-update of the Kubernetes version is disabled", func() {
+				var (
+					ctx          = context.Background()
+					fakeClock    = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: false},
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				fakeRecorder := record.NewFakeRecorder(8)
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, Recorder: fakeRecorder}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.0"))
+
+				Expect(drainEvents(fakeRecorder)).To(ContainElement(And(
+					ContainSubstring("MaintenanceUpdateSkipped"),
+					ContainSubstring(`Control Plane: Skipped Kubernetes version update: auto-update is disabled and version "1.26.0" is not expired`),
+				)))
+			})
+
+			It("should record that the default maintenance time window was applied to a window-less Shoot", func() {
+				var (
+					ctx          = context.Background()
+					fakeClock    = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true},
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{
+					Client:   fakeClient,
+					Clock:    fakeClock,
+					Recorder: record.NewFakeRecorder(8),
+					Config: config.ShootMaintenanceControllerConfiguration{
+						DefaultTimeWindow: &config.MaintenanceTimeWindow{Begin: "130000+0000", End: "150000+0000"},
+					},
+				}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.1"))
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.Description).To(ContainSubstring("Maintenance was scheduled by the default maintenance time window (130000+0000-150000+0000) of the controller because the Shoot does not declare an own one"))
+			})
+
+			It("should inherit maintenance settings from a referenced MaintenancePolicy without persisting them", func() {
+				var (
+					ctx          = context.Background()
+					fakeClock    = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					policy = &gardencorev1beta1.MaintenancePolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: "default-policy"},
+						Spec: gardencorev1beta1.MaintenancePolicySpec{
+							TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "130000+0000", End: "150000+0000"},
+							AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "shoot",
+							Namespace:   "garden-foo",
+							Annotations: map[string]string{AnnotationMaintenancePolicy: "default-policy"},
+						},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile, policy).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{
+					Client:   fakeClient,
+					Clock:    fakeClock,
+					Recorder: record.NewFakeRecorder(8),
+				}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.1"), "the inherited auto-update setting should cause a Kubernetes version update")
+				Expect(persistedShoot.Spec.Maintenance).To(BeNil(), "the inherited maintenance settings should not be persisted")
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.Description).To(ContainSubstring(`Maintenance settings not configured on the Shoot were inherited from MaintenancePolicy "default-policy"`))
+			})
+
+			Describe("maintenance time window validation", func() {
+				var (
+					ctx          context.Context
+					fakeClock    *testclock.FakeClock
+					fakeClient   client.Client
+					cloudProfile *gardencorev1beta1.CloudProfile
+					shoot        *gardencorev1beta1.Shoot
+					reconciler   *Reconciler
+				)
+
+				BeforeEach(func() {
+					ctx = context.Background()
+					fakeClock = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true},
+								TimeWindow: &gardencorev1beta1.MaintenanceTimeWindow{Begin: "140000+0000", End: "140500+0000"},
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+
+					fakeClient = fakeclient.NewClientBuilder().
+						WithScheme(kubernetes.GardenScheme).
+						WithObjects(shoot, cloudProfile).
+						WithStatusSubresource(shoot).
+						Build()
+					reconciler = &Reconciler{
+						Client:   fakeClient,
+						Clock:    fakeClock,
+						Recorder: record.NewFakeRecorder(8),
+						Config: config.ShootMaintenanceControllerConfiguration{
+							MinTimeWindowDuration: &metav1.Duration{Duration: 30 * time.Minute},
+						},
+					}
+				})
+
+				It("should refuse to maintain a Shoot with a too short maintenance time window", func() {
+					Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+					persistedShoot := &gardencorev1beta1.Shoot{}
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+					Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.0"), "no update should be performed")
+					Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+					Expect(persistedShoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateFailed))
+					Expect(persistedShoot.Status.LastMaintenance.FailureReason).To(PointTo(ContainSubstring("must last at least 30m0s")))
+				})
+
+				It("should refuse to maintain a Shoot with a malformed maintenance time window", func() {
+					shoot.Spec.Maintenance.TimeWindow.Begin = "invalid"
+					Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+					Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+					persistedShoot := &gardencorev1beta1.Shoot{}
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+					Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.0"), "no update should be performed")
+					Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+					Expect(persistedShoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateFailed))
+					Expect(persistedShoot.Status.LastMaintenance.FailureReason).To(PointTo(ContainSubstring("could not parse begin time")))
+				})
+
+				It("should maintain a Shoot with a sufficiently long maintenance time window", func() {
+					shoot.Spec.Maintenance.TimeWindow.End = "150000+0000"
+					Expect(fakeClient.Update(ctx, shoot)).To(Succeed())
+
+					Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+					persistedShoot := &gardencorev1beta1.Shoot{}
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+					Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.1"))
+				})
+			})
+
+			It("should list available-but-unapplied updates in the maintenance status when auto-update is disabled", func() {
+				var (
+					ctx          = context.Background()
+					fakeClock    = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: false},
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{
+					Client:   fakeClient,
+					Clock:    fakeClock,
+					Recorder: record.NewFakeRecorder(8),
+				}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.0"), "no update should be applied")
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.AvailableUpdates).To(ConsistOf(
+					gardencorev1beta1.AvailableUpdate{Target: "Control Plane", Version: "1.26.1"},
+				))
+			})
+
+			It("should emit a skip event when no higher Kubernetes version is available", func() {
+				var (
+					ctx          = context.Background()
+					fakeClock    = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					cloudProfile = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.1"},
+								},
+							},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.1"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true},
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				fakeRecorder := record.NewFakeRecorder(8)
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, Recorder: fakeRecorder}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				Expect(drainEvents(fakeRecorder)).To(ContainElement(And(
+					ContainSubstring("MaintenanceUpdateSkipped"),
+					ContainSubstring(`Control Plane: Skipped Kubernetes version update: no higher suitable version than "1.26.1" found in the CloudProfile`),
+				)))
+			})
+
+			It("should materialize the maintenance plan as a ConfigMap when the feature gate is enabled", func() {
+				var (
+					ctx           = context.Background()
+					fakeClock     = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					strategyMajor = gardencorev1beta1.UpdateStrategyMajor
+					cloudProfile  = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+							MachineImages: []gardencorev1beta1.MachineImage{{
+								Name:           "foo-image",
+								UpdateStrategy: &strategyMajor,
+								Versions: []gardencorev1beta1.MachineImageVersion{{
+									ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.1.1"},
+									CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+									Architectures:    []string{"amd64"},
+								}},
+							}},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true, MachineImageVersion: pointer.Bool(false)},
+							},
+							Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+								Name: "worker",
+								Machine: gardencorev1beta1.Machine{
+									Image:        &gardencorev1beta1.ShootMachineImage{Name: "foo-image", Version: pointer.String("1.1.1")},
+									Architecture: pointer.String("amd64"),
+								},
+								Kubernetes: &gardencorev1beta1.WorkerKubernetes{Version: pointer.String("1.26.0")},
+							}}},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{
+					Client:   fakeClient,
+					Clock:    fakeClock,
+					Recorder: record.NewFakeRecorder(8),
+					Config:   config.ShootMaintenanceControllerConfiguration{FeatureGates: map[string]bool{FeaturePlanConfigMap: true}},
+				}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				configMap := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "garden-foo", Name: "shoot.maintenance-plan"}, configMap)).To(Succeed())
+				plan := configMap.Data["plan.yaml"]
+				Expect(plan).To(ContainSubstring("target: Control Plane"))
+				Expect(plan).To(ContainSubstring(`target: Worker pool "worker"`))
+				Expect(plan).To(ContainSubstring("fromVersion: 1.26.0"))
+				Expect(plan).To(ContainSubstring("toVersion: 1.26.1"))
+			})
+
+			It("should refuse to maintain a worker pool whose Kubernetes version is higher than the control plane", func() {
+				var (
+					ctx           = context.Background()
+					fakeClock     = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					strategyMajor = gardencorev1beta1.UpdateStrategyMajor
+					cloudProfile  = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+									{Version: "1.27.0"},
+								},
+							},
+							MachineImages: []gardencorev1beta1.MachineImage{{
+								Name:           "foo-image",
+								UpdateStrategy: &strategyMajor,
+								Versions: []gardencorev1beta1.MachineImageVersion{{
+									ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.1.1"},
+									CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+									Architectures:    []string{"amd64"},
+								}},
+							}},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true, MachineImageVersion: pointer.Bool(false)},
+							},
+							Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+								Name: "worker",
+								Machine: gardencorev1beta1.Machine{
+									Image:        &gardencorev1beta1.ShootMachineImage{Name: "foo-image", Version: pointer.String("1.1.1")},
+									Architecture: pointer.String("amd64"),
+								},
+								Kubernetes: &gardencorev1beta1.WorkerKubernetes{Version: pointer.String("1.27.0")},
+							}}},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, Recorder: record.NewFakeRecorder(8)}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				// the control plane is updated to the latest patch version, but the worker pool must not be touched
+				Expect(persistedShoot.Spec.Kubernetes.Version).To(Equal("1.26.1"))
+				Expect(persistedShoot.Spec.Provider.Workers[0].Kubernetes.Version).To(PointTo(Equal("1.27.0")))
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+				Expect(persistedShoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateFailed))
+				Expect(persistedShoot.Status.LastMaintenance.Description).To(ContainSubstring("Worker pool Kubernetes version is higher than the control plane version"))
+				Expect(persistedShoot.Status.LastMaintenance.FailureReason).To(PointTo(ContainSubstring(`Refused to update the Kubernetes version of worker pool "worker": version "1.27.0" is higher than the control plane version "1.26.1" - the control plane must be updated first`)))
+			})
+
+			It("should report per-target timings in the maintenance status", func() {
+				var (
+					ctx           = context.Background()
+					fakeClock     = testclock.NewFakeClock(time.Date(2023, 5, 3, 14, 0, 0, 0, time.UTC))
+					strategyMajor = gardencorev1beta1.UpdateStrategyMajor
+					cloudProfile  = &gardencorev1beta1.CloudProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "profile"},
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							Kubernetes: gardencorev1beta1.KubernetesSettings{
+								Versions: []gardencorev1beta1.ExpirableVersion{
+									{Version: "1.26.0"},
+									{Version: "1.26.1"},
+								},
+							},
+							MachineImages: []gardencorev1beta1.MachineImage{{
+								Name:           "foo-image",
+								UpdateStrategy: &strategyMajor,
+								Versions: []gardencorev1beta1.MachineImageVersion{{
+									ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.1.1"},
+									CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+									Architectures:    []string{"amd64"},
+								}},
+							}},
+						},
+					}
+					shoot = &gardencorev1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+						Spec: gardencorev1beta1.ShootSpec{
+							CloudProfileName: "profile",
+							Kubernetes:       gardencorev1beta1.Kubernetes{Version: "1.26.0"},
+							Maintenance: &gardencorev1beta1.Maintenance{
+								AutoUpdate: &gardencorev1beta1.MaintenanceAutoUpdate{KubernetesVersion: true, MachineImageVersion: pointer.Bool(false)},
+							},
+							Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{{
+								Name: "worker",
+								Machine: gardencorev1beta1.Machine{
+									Image:        &gardencorev1beta1.ShootMachineImage{Name: "foo-image", Version: pointer.String("1.1.1")},
+									Architecture: pointer.String("amd64"),
+								},
+								Kubernetes: &gardencorev1beta1.WorkerKubernetes{Version: pointer.String("1.26.0")},
+							}}},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+						},
+					}
+				)
+
+				fakeClient := fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot, cloudProfile).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler := &Reconciler{Client: fakeClient, Clock: fakeClock, Recorder: record.NewFakeRecorder(8)}
+
+				Expect(reconciler.reconcile(ctx, log, shoot)).To(Succeed())
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Status.LastMaintenance).NotTo(BeNil())
+
+				changes := persistedShoot.Status.LastMaintenance.Changes
+				Expect(changes).To(HaveLen(3))
+				Expect(changes[0].Target).To(Equal("Machine images"))
+				Expect(changes[1].Target).To(Equal("Control Plane"))
+				Expect(changes[2].Target).To(Equal(`Worker pool "worker"`))
+				for _, change := range changes {
+					Expect(change.StartTime).NotTo(BeNil())
+					Expect(change.EndTime).NotTo(BeNil())
+					Expect(change.EndTime.Time.Before(change.StartTime.Time)).To(BeFalse(), "duration must be non-negative")
+				}
+			})
+		})
+
+		Describe("#notifyForcefulUpdates", func() {
+			var (
+				ctx    context.Context
+				server *httptest.Server
+
+				requestCount int
+				payloads     []ForceUpdateNotification
+
+				shoot *gardencorev1beta1.Shoot
+			)
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				requestCount = 0
+				payloads = nil
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requestCount++
+					payload := ForceUpdateNotification{}
+					Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+					payloads = append(payloads, payload)
+					w.WriteHeader(http.StatusOK)
+				}))
+				DeferCleanup(server.Close)
+
+				shoot = &gardencorev1beta1.Shoot{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"}}
+			})
+
+			It("should POST the payload for a successful force-update", func() {
+				reconciler := &Reconciler{Notifier: NewWebhookNotifier(server.URL)}
+
+				reconciler.notifyForcefulUpdates(ctx, log, shoot, &updateResult{
+					reason:       "Kubernetes version expired - force update required",
+					isSuccessful: true,
+					isForceful:   true,
+					fromVersion:  "1.25.10",
+					toVersion:    "1.26.4",
+				}, nil, nil)
+
+				Expect(payloads).To(ConsistOf(ForceUpdateNotification{
+					Shoot:       "shoot",
+					Namespace:   "garden-foo",
+					Target:      "control-plane/kubernetes",
+					FromVersion: "1.25.10",
+					ToVersion:   "1.26.4",
+					Reason:      "Kubernetes version expired - force update required",
+				}))
+			})
+
+			It("should not notify for auto-updates or failed updates", func() {
+				reconciler := &Reconciler{Notifier: NewWebhookNotifier(server.URL)}
+
+				reconciler.notifyForcefulUpdates(ctx, log, shoot,
+					&updateResult{reason: "Automatic update of Kubernetes version configured", isSuccessful: true, fromVersion: "1.25.10", toVersion: "1.25.11"},
+					map[string]updateResult{"cpu-worker": {reason: "Kubernetes version expired - force update required", isForceful: true, isSuccessful: false}},
+					nil,
+				)
+
+				Expect(requestCount).To(BeZero())
+			})
+
+			It("should retry the delivery a bounded number of times", func() {
+				failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					requestCount++
+					if requestCount == 1 {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				}))
+				DeferCleanup(failingServer.Close)
+
+				Expect(NewWebhookNotifier(failingServer.URL).Notify(ctx, ForceUpdateNotification{})).To(Succeed())
+				Expect(requestCount).To(Equal(2))
+			})
+		})
+
+		Describe("#failLongRunningMaintenance", func() {
+			var (
+				ctx        context.Context
+				fakeClient client.Client
+				fakeClock  *testclock.FakeClock
+				reconciler *Reconciler
+				shoot      *gardencorev1beta1.Shoot
+
+				maxDuration = time.Hour
+			)
+
+			BeforeEach(func() {
+				ctx = context.Background()
+				fakeClock = testclock.NewFakeClock(time.Now())
+				shoot = &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: "garden-foo"},
+					Status: gardencorev1beta1.ShootStatus{
+						LastMaintenance: &gardencorev1beta1.LastMaintenance{
+							State:         gardencorev1beta1.LastOperationStateProcessing,
+							TriggeredTime: metav1.Time{Time: fakeClock.Now()},
+						},
+					},
+				}
+				fakeClient = fakeclient.NewClientBuilder().
+					WithScheme(kubernetes.GardenScheme).
+					WithObjects(shoot).
+					WithStatusSubresource(shoot).
+					Build()
+				reconciler = &Reconciler{
+					Client: fakeClient,
+					Config: config.ShootMaintenanceControllerConfiguration{MaxDuration: &metav1.Duration{Duration: maxDuration}},
+					Clock:  fakeClock,
+				}
+			})
+
+			It("should not touch a maintenance operation within the maximum duration", func() {
+				fakeClock.Step(maxDuration)
+
+				Expect(reconciler.failLongRunningMaintenance(ctx, log, shoot)).To(Succeed())
+				Expect(shoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateProcessing))
+			})
+
+			It("should mark a maintenance operation exceeding the maximum duration as failed", func() {
+				fakeClock.Step(maxDuration + time.Minute)
+
+				Expect(reconciler.failLongRunningMaintenance(ctx, log, shoot)).To(Succeed())
+				Expect(shoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateFailed))
+				Expect(shoot.Status.LastMaintenance.FailureReason).To(PointTo(ContainSubstring("did not complete within the configured maximum duration")))
+
+				persistedShoot := &gardencorev1beta1.Shoot{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(shoot), persistedShoot)).To(Succeed())
+				Expect(persistedShoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateFailed))
+			})
 
-	BeforeEach(func() {
-		log = logr.Discard()
-		now = time.Now()
-		expirationDateInTheFuture = metav1.Time{Time: now.Add(time.Minute * 10)}
-		expirationDateInThePast = metav1.Time{Time: now.AddDate(0, 0, -1)}
-	})
+			It("should do nothing when no maximum duration is configured", func() {
+				reconciler.Config.MaxDuration = nil
+				fakeClock.Step(24 * time.Hour)
+
+				Expect(reconciler.failLongRunningMaintenance(ctx, log, shoot)).To(Succeed())
+				Expect(shoot.Status.LastMaintenance.State).To(Equal(gardencorev1beta1.LastOperationStateProcessing))
+			})
+		})
 
-	Context("Shoot Maintenance", func() {
 		Describe("#ExpirationDateExpired", func() {
 			It("should determine that expirationDate applies", func() {
 				applies := ExpirationDateExpired(&expirationDateInThePast)
@@ -55,6 +1587,66 @@ var _ = Describe("Shoot Maintenance", func() {
 		})
 	})
 
+	Describe("#ResolveImageForCRI", func() {
+		var machineImage *gardencorev1beta1.MachineImage
+
+		BeforeEach(func() {
+			strategyMajor := gardencorev1beta1.UpdateStrategyMajor
+			machineImage = &gardencorev1beta1.MachineImage{
+				Name:           "CoreOs",
+				UpdateStrategy: &strategyMajor,
+				Versions: []gardencorev1beta1.MachineImageVersion{
+					{
+						ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.0.0"},
+						CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+						Architectures:    []string{"amd64"},
+					},
+					{
+						ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.1.0"},
+						CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameContainerD}},
+						Architectures:    []string{"amd64", "arm64"},
+					},
+					{
+						ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.2.0"},
+						CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}, {Name: gardencorev1beta1.CRINameContainerD}},
+						Architectures:    []string{"arm64"},
+					},
+					{
+						ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.3.0"},
+						CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}, {Name: gardencorev1beta1.CRINameContainerD}},
+						Architectures:    []string{"amd64", "arm64"},
+					},
+				},
+			}
+		})
+
+		versionsOf := func(machineImage *gardencorev1beta1.MachineImage) []string {
+			var versions []string
+			for _, version := range machineImage.Versions {
+				versions = append(versions, version.Version)
+			}
+			return versions
+		}
+
+		DescribeTable("should return the eligible versions for the target architecture and CRI",
+			func(arch string, criName gardencorev1beta1.CRIName, expectedVersions []string) {
+				resolved := ResolveImageForCRI(machineImage, &arch, &gardencorev1beta1.CRI{Name: criName})
+				Expect(resolved.Name).To(Equal("CoreOs"))
+				Expect(versionsOf(resolved)).To(ConsistOf(expectedVersions))
+			},
+
+			Entry("docker on amd64", "amd64", gardencorev1beta1.CRINameDocker, []string{"1.0.0", "1.3.0"}),
+			Entry("docker on arm64", "arm64", gardencorev1beta1.CRINameDocker, []string{"1.2.0", "1.3.0"}),
+			Entry("containerd on amd64", "amd64", gardencorev1beta1.CRINameContainerD, []string{"1.1.0", "1.3.0"}),
+			Entry("containerd on arm64", "arm64", gardencorev1beta1.CRINameContainerD, []string{"1.1.0", "1.2.0", "1.3.0"}),
+		)
+
+		It("should default an unset CRI configuration to docker", func() {
+			resolved := ResolveImageForCRI(machineImage, pointer.String("amd64"), nil)
+			Expect(versionsOf(resolved)).To(ConsistOf("1.0.0", "1.3.0"))
+		})
+	})
+
 	Describe("#MaintainMachineImages", func() {
 		var (
 			shootCurrentImage        *gardencorev1beta1.ShootMachineImage
@@ -132,6 +1724,81 @@ var _ = Describe("Shoot Maintenance", func() {
 			}
 		})
 
+		Describe("CRI migration", func() {
+			BeforeEach(func() {
+				// the current version expired and the only higher version dropped support for docker
+				cloudProfile.Spec.MachineImages[0].Versions[0].ExpirationDate = &expirationDateInThePast
+				cloudProfile.Spec.MachineImages[0].Versions[1].CRI = []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameContainerD}}
+				shoot.Spec.Provider.Workers[0].CRI = &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameDocker}
+			})
+
+			It("should migrate the worker pool to containerd when the only valid higher image drops docker", func() {
+				results, err := maintainMachineImages(log, shoot, cloudProfile, true)
+
+				Expect(err).NotTo(HaveOccurred())
+				result := results["cpu-worker"]
+				Expect(result.isSuccessful).To(BeTrue())
+				Expect(result.isForceful).To(BeTrue())
+				Expect(result.description).To(ContainSubstring(`migrated the container runtime of worker pool "cpu-worker" from "docker" to "containerd"`))
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
+				Expect(shoot.Spec.Provider.Workers[0].CRI).To(Equal(&gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}))
+			})
+
+			It("should not migrate the container runtime when the option is disabled", func() {
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				result := results["cpu-worker"]
+				Expect(result.isSuccessful).To(BeFalse())
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", shootCurrentImageVersion)
+				Expect(shoot.Spec.Provider.Workers[0].CRI).To(Equal(&gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameDocker}))
+			})
+		})
+
+		Describe("maximum version ceiling", func() {
+			var intermediateVersion = "1.2.0"
+
+			BeforeEach(func() {
+				cloudProfile.Spec.MachineImages[0].Versions = append(cloudProfile.Spec.MachineImages[0].Versions, gardencorev1beta1.MachineImageVersion{
+					ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: intermediateVersion},
+					CRI:              []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+					Architectures:    []string{"amd64"},
+				})
+				shoot.Spec.Provider.Workers[0].Machine.Image.MaximumVersion = pointer.String(intermediateVersion)
+			})
+
+			It("should bound the auto-update to the configured maximum version instead of the overall latest", func() {
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results["cpu-worker"].isForceful).To(BeFalse())
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", intermediateVersion)
+			})
+
+			It("should not update a worker which is already at the configured maximum version", func() {
+				shoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String(intermediateVersion)
+
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).NotTo(HaveKey("cpu-worker"))
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", intermediateVersion)
+			})
+
+			It("should exceed the configured maximum version with a note when the current version is expired", func() {
+				cloudProfile.Spec.MachineImages[0].Versions[0].ExpirationDate = &expirationDateInThePast
+				shoot.Spec.Provider.Workers[0].Machine.Image.MaximumVersion = pointer.String("1.0.5")
+
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				result := results["cpu-worker"]
+				Expect(result.isForceful).To(BeTrue())
+				Expect(result.description).To(ContainSubstring(`The configured maximum version "1.0.5" was exceeded because the current version is expired`))
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
+			})
+		})
+
 		Describe("UpdateStrategy: Major", func() {
 			BeforeEach(func() {
 				updateStrategyMajor := gardencorev1beta1.UpdateStrategyMajor
@@ -139,10 +1806,52 @@ var _ = Describe("Shoot Maintenance", func() {
 			})
 
 			It("should update machine image version to overall latest. Auto update: already on latest patch for minor, and there is an overall higher version available", func() {
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
+			})
+
+			It("should only auto-update machine images listed in the auto-update annotation", func() {
+				updateStrategyMajor := gardencorev1beta1.UpdateStrategyMajor
+				cloudProfile.Spec.MachineImages = append(cloudProfile.Spec.MachineImages, gardencorev1beta1.MachineImage{
+					Name:           "GpuOs",
+					UpdateStrategy: &updateStrategyMajor,
+					Versions: []gardencorev1beta1.MachineImageVersion{
+						{
+							ExpirableVersion: gardencorev1beta1.ExpirableVersion{
+								Version: shootCurrentImageVersion,
+							},
+							CRI:           []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+							Architectures: []string{"amd64"},
+						},
+						{
+							ExpirableVersion: gardencorev1beta1.ExpirableVersion{
+								Version:        overallLatestVersion,
+								ExpirationDate: &expirationDateInTheFuture,
+							},
+							CRI:           []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+							Architectures: []string{"amd64"},
+						},
+					},
+				})
+				shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{
+					Name: "gpu-worker",
+					Machine: gardencorev1beta1.Machine{
+						Image: &gardencorev1beta1.ShootMachineImage{
+							Name:    "GpuOs",
+							Version: pointer.String(shootCurrentImageVersion),
+						},
+						Architecture: pointer.String("amd64"),
+					},
+				})
+				shoot.Annotations = map[string]string{AnnotationAutoUpdateMachineImageNames: "CoreOs"}
+
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[1], "GpuOs", shootCurrentImageVersion)
 			})
 
 			It("should update machine image version to overall latest for correct architecture. Auto update: already on latest patch for minor, and there is an overall higher version available", func() {
@@ -175,7 +1884,7 @@ var _ = Describe("Shoot Maintenance", func() {
 
 				shoot.Spec.Provider.Workers[0].Machine.Architecture = pointer.String("arm64")
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expectedVersion)
 			})
@@ -218,7 +1927,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 
 				shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, otherWorker)
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 
@@ -238,7 +1947,7 @@ var _ = Describe("Shoot Maintenance", func() {
 
 				cloudProfile.Spec.MachineImages[0].Versions = append(cloudProfile.Spec.MachineImages[0].Versions, highestForMinor)
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expectedVersion)
@@ -248,7 +1957,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion = pointer.Bool(false)
 				cloudProfile.Spec.MachineImages[0].Versions[0].ExpirationDate = &expirationDateInThePast
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
@@ -283,10 +1992,12 @@ var _ = Describe("Shoot Maintenance", func() {
 					},
 				}
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expectedVersion)
+				// the force update stayed within the current minor, so it must be reported as a patch catch-up
+				Expect(results["cpu-worker"].reason).To(Equal("Machine image version expired - patch catch-up before force update (image update strategy: major)"))
 			})
 
 			It("should return a maintenance failure - edge case: all qualifying versions from the CloudProfile for machine image are lower than the Shoot's version (Shoot has the highest version and it is expired). Should not downgrade shoot machine image version.", func() {
@@ -299,7 +2010,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 				cloudProfile.Spec.MachineImages[0].Versions = append(cloudProfile.Spec.MachineImages[0].Versions, highestExpiredVersion)
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &highestExpiredVersion.Version
-				results, err := maintainMachineImages(log, shoot, cloudProfile)
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(results[shoot.Spec.Provider.Workers[0].Name].isSuccessful).To(BeFalse())
 				Expect(err).ToNot(HaveOccurred())
@@ -307,7 +2018,7 @@ var _ = Describe("Shoot Maintenance", func() {
 
 			It("should not change version: already on highest version.", func() {
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &overallLatestVersion
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
@@ -342,7 +2053,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				cloudProfile.Spec.MachineImages[0].Versions = versions
 
 				// the shoots patch version is expired and there is no higher non-expired & non-preview patch version of the same minor -> force update
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expectedVersion)
@@ -398,7 +2109,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 
 				// the shoots patch version is expired and there is no higher non-expired & non-preview patch version of the same minor -> force update
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expectedVersion)
@@ -441,7 +2152,7 @@ var _ = Describe("Shoot Maintenance", func() {
 					},
 				}
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestPatchNextMinor)
@@ -477,7 +2188,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				cloudProfile.Spec.MachineImages[0].Versions = versions
 
 				// the shoots patch version is expired and there is no higher non-expired & non-preview patch version of the same minor -> force update
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestNonPreviewPatchVersionNplusTwoMinor.Version)
@@ -522,7 +2233,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				cloudProfile.Spec.MachineImages[0].Versions = versions
 
 				// the shoots patch version is expired and there is no higher non-expired & non-preview patch version of the same minor -> force update
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expiredPatchVersionNextMinor.Version)
@@ -556,7 +2267,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &highestVersionForMinor
 				expected := shoot.Spec.Provider.Workers[0].Machine.Image.DeepCopy()
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(shoot.Spec.Provider.Workers[0].Machine.Image).To(Equal(expected))
 			})
@@ -571,13 +2282,39 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 				cloudProfile.Spec.MachineImages[0].Versions = append(cloudProfile.Spec.MachineImages[0].Versions, highestExpiredVersion)
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &highestExpiredVersion.Version
-				results, err := maintainMachineImages(log, shoot, cloudProfile)
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(results[shoot.Spec.Provider.Workers[0].Name].isSuccessful).To(BeFalse())
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
+		Describe("UpdateStrategy: overridden on the Shoot", func() {
+			It("should force-update to the latest patch version for this minor when the Shoot overrides the CloudProfile's major strategy with patch", func() {
+				strategyPatch := gardencorev1beta1.UpdateStrategyPatch
+				expectedVersion := "1.0.2"
+
+				// cause force update: disable auto update and expire Shoot's OS version. Without the override, the
+				// CloudProfile's major strategy would force an update to the overall latest version.
+				shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion = pointer.Bool(false)
+				cloudProfile.Spec.MachineImages[0].Versions[0].ExpirationDate = &expirationDateInThePast
+				cloudProfile.Spec.MachineImages[0].Versions = append(cloudProfile.Spec.MachineImages[0].Versions, gardencorev1beta1.MachineImageVersion{
+					ExpirableVersion: gardencorev1beta1.ExpirableVersion{
+						Version:        expectedVersion,
+						ExpirationDate: &expirationDateInTheFuture,
+					},
+					CRI:           []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameDocker}},
+					Architectures: []string{"amd64"},
+				})
+				shoot.Spec.Provider.Workers[0].Machine.Image.UpdateStrategy = &strategyPatch
+
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", expectedVersion)
+			})
+		})
+
 		Describe("UpdateStrategy: minor", func() {
 			It("should auto-update to the latest patch version for this minor before considering an update to the latest version in the major. Auto update: not on latest minor.patch version in major", func() {
 				autoUpdateStrategyMinor := gardencorev1beta1.UpdateStrategyMinor
@@ -623,7 +2360,7 @@ var _ = Describe("Shoot Maintenance", func() {
 					},
 				}
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestPatchCurrentMinor)
@@ -672,7 +2409,7 @@ var _ = Describe("Shoot Maintenance", func() {
 					},
 				}
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestVersionForCurrentMajor)
@@ -722,7 +2459,7 @@ var _ = Describe("Shoot Maintenance", func() {
 					},
 				}
 
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestVersionForCurrentMajor)
@@ -768,7 +2505,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &latestVersionForCurrentMajor
 
 				// the shoots patch version is expired and there is no higher non-expired & non-preview patch version of the same minor -> force update
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", latestVersionNextMajor)
@@ -804,7 +2541,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				cloudProfile.Spec.MachineImages[0].Versions = versions
 
 				// the shoots patch version is expired and there is no higher non-expired & non-preview patch version of the same minor -> force update
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestNonPreviewVersionNplusTwoMajor.Version)
@@ -820,7 +2557,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 				cloudProfile.Spec.MachineImages[0].Versions = append(cloudProfile.Spec.MachineImages[0].Versions, highestExpiredVersion)
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &highestExpiredVersion.Version
-				results, err := maintainMachineImages(log, shoot, cloudProfile)
+				results, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(results[shoot.Spec.Provider.Workers[0].Name].isSuccessful).To(BeFalse())
 				Expect(err).ToNot(HaveOccurred())
@@ -856,7 +2593,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				}
 
 				shoot.Spec.Provider.Workers[0].Machine.Image.Version = &highestVersionForMajor
-				_, err := maintainMachineImages(log, shoot, cloudProfile)
+				_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 				Expect(err).NotTo(HaveOccurred())
 				assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", highestVersionForMajor)
@@ -866,7 +2603,7 @@ var _ = Describe("Shoot Maintenance", func() {
 		It("should treat workers with `cri: nil` like `cri.name: docker` and not update if `docker` is not explicitly supported by the machine image", func() {
 			cloudProfile.Spec.MachineImages[0].Versions[1].CRI = []gardencorev1beta1.CRI{{Name: gardencorev1beta1.CRINameContainerD}}
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 			assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", "1.0.0")
 		})
@@ -875,7 +2612,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion = pointer.Bool(false)
 
 			expected := shoot.Spec.Provider.Workers[0].Machine.Image.DeepCopy()
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(shoot.Spec.Provider.Workers[0].Machine.Image).To(Equal(expected))
@@ -886,7 +2623,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			shoot.Spec.Provider.Workers[0].CRI = &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}
 
 			expected := shoot.Spec.Provider.Workers[0].Machine.Image.DeepCopy()
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(shoot.Spec.Provider.Workers[0].Machine.Image).To(Equal(expected))
 		})
@@ -899,7 +2636,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			// add another pool without CRI constraints -> should be updated via auto-update
 			shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{Name: "worker-without-cri-config", Machine: gardencorev1beta1.Machine{Image: shootCurrentImage.DeepCopy(), Architecture: pointer.String("amd64")}})
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", "1.0.0")
@@ -915,7 +2652,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			// add another pool without CRI constraints -> should be updated via auto-update to the highest patch version of the same minor
 			shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{Name: "worker-without-containerruntime", CRI: &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD}, Machine: gardencorev1beta1.Machine{Image: shootCurrentImage.DeepCopy(), Architecture: pointer.String("amd64")}})
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", "1.0.0")
@@ -930,7 +2667,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{Name: "worker-with-gvisor-and-kata", CRI: &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD, ContainerRuntimes: []gardencorev1beta1.ContainerRuntime{{Type: "gvisor"}, {Type: "kata-container"}}}, Machine: gardencorev1beta1.Machine{Image: shootCurrentImage.DeepCopy(), Architecture: pointer.String("amd64")}})
 			shoot.Spec.Provider.Workers = append(shoot.Spec.Provider.Workers, gardencorev1beta1.Worker{Name: "worker-with-gvisor", CRI: &gardencorev1beta1.CRI{Name: gardencorev1beta1.CRINameContainerD, ContainerRuntimes: []gardencorev1beta1.ContainerRuntime{{Type: "gvisor"}}}, Machine: gardencorev1beta1.Machine{Image: shootCurrentImage.DeepCopy(), Architecture: pointer.String("amd64")}})
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", "1.0.0")
@@ -943,7 +2680,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			shoot.Spec.Kubernetes.Version = "1.26.0"
 
 			expected := shoot.Spec.Provider.Workers[0].Machine.Image.DeepCopy()
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(shoot.Spec.Provider.Workers[0].Machine.Image).To(Equal(expected))
 		})
@@ -952,7 +2689,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			cloudProfile.Spec.MachineImages[0].Versions[1].KubeletVersionConstraint = pointer.String("< 1.26")
 			shoot.Spec.Kubernetes.Version = "1.25.1"
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 			assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", overallLatestVersion)
 		})
@@ -965,7 +2702,7 @@ var _ = Describe("Shoot Maintenance", func() {
 			}
 
 			expected := shoot.Spec.Provider.Workers[0].Machine.Image.DeepCopy()
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(shoot.Spec.Provider.Workers[0].Machine.Image).To(Equal(expected))
 		})
@@ -978,7 +2715,7 @@ var _ = Describe("Shoot Maintenance", func() {
 				Version: pointer.String("1.26.0"),
 			}
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 			Expect(err).NotTo(HaveOccurred())
 			assertWorkerMachineImageVersion(&shoot.Spec.Provider.Workers[0], "CoreOs", cloudProfile.Spec.MachineImages[0].Versions[1].Version)
 		})
@@ -986,10 +2723,35 @@ var _ = Describe("Shoot Maintenance", func() {
 		It("should return an error - cloud profile has no matching (machineImage.name) machine image defined", func() {
 			cloudProfile.Spec.MachineImages = cloudProfile.Spec.MachineImages[1:]
 
-			_, err := maintainMachineImages(log, shoot, cloudProfile)
+			_, err := maintainMachineImages(log, shoot, cloudProfile, false)
 
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should report the uniform NoHigherVersionAvailable reason when the worker image is already at the latest version", func() {
+			shoot.Spec.Provider.Workers[0].Machine.Image = &gardencorev1beta1.ShootMachineImage{Name: "CoreOs", Version: pointer.String(overallLatestVersion)}
+			cloudProfile.Spec.MachineImages[0].Versions[1].ExpirationDate = &expirationDateInThePast
+
+			results, err := maintainMachineImages(log, shoot, cloudProfile, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results["cpu-worker"].description).To(Equal(`NoHigherVersionAvailable: no version higher than "1.5.3" exists within the bounds of the update strategy`))
+		})
+	})
+
+	Describe("#ensureNoDowngrade", func() {
+		It("should refuse a lower determined version with the WouldDowngrade reason", func() {
+			err := ensureNoDowngrade("1.1.3", "1.1.2")
+			Expect(err).To(MatchError(`WouldDowngrade: refusing to update version "1.1.3" to lower version "1.1.2" determined by the update strategy`))
+		})
+
+		It("should allow a higher determined version", func() {
+			Expect(ensureNoDowngrade("1.1.3", "1.2.0")).To(Succeed())
+		})
+
+		It("should allow an equal determined version", func() {
+			Expect(ensureNoDowngrade("1.1.3", "1.1.3")).To(Succeed())
+		})
 	})
 
 	Describe("#maintainKubernetesVersion", func() {
@@ -1050,6 +2812,23 @@ var _ = Describe("Shoot Maintenance", func() {
 				},
 			}
 		})
+		It("should not change the version when the only remaining candidate in the CloudProfile is lower than the current version", func() {
+			shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = false
+			// the Shoot's version was removed from the CloudProfile, the only remaining version of the minor is lower
+			shoot.Spec.Kubernetes = gardencorev1beta1.Kubernetes{Version: "1.1.3"}
+			cloudProfile.Spec.Kubernetes.Versions = []gardencorev1beta1.ExpirableVersion{{Version: "1.1.2"}}
+
+			result, err := maintainKubernetesVersion(log, shoot.Spec.Kubernetes.Version, shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile, func(v string) error {
+				shoot.Spec.Kubernetes.Version = v
+				return nil
+			})
+
+			Expect(err).To(HaveOccurred())
+			Expect(result).NotTo(BeNil())
+			Expect(result.isSuccessful).To(BeFalse())
+			Expect(shoot.Spec.Kubernetes.Version).To(Equal("1.1.3"))
+		})
+
 		It("should determine that the shoot kubernetes version must be maintained - ForceUpdate to latest patch version", func() {
 			shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = false
 			cloudProfile.Spec.Kubernetes.Versions[4].ExpirationDate = &expirationDateInThePast
@@ -1148,6 +2927,22 @@ var _ = Describe("Shoot Maintenance", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("should report the uniform NoHigherVersionAvailable reason when the control plane is already at the latest version", func() {
+			shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = false
+			// expire the overall latest version which has no higher version in the CloudProfile
+			cloudProfile.Spec.Kubernetes.Versions[6].ExpirationDate = &expirationDateInThePast
+			shoot.Spec.Kubernetes = gardencorev1beta1.Kubernetes{Version: "2.0.0"}
+
+			result, err := maintainKubernetesVersion(log, shoot.Spec.Kubernetes.Version, shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion, cloudProfile, func(v string) error {
+				shoot.Spec.Kubernetes.Version = v
+				return nil
+			})
+
+			Expect(err).To(MatchError(ErrNoHigherVersionAvailable))
+			Expect(result.description).To(Equal(`NoHigherVersionAvailable: no version higher than "2.0.0" exists within the bounds of the update strategy`))
+			Expect(shoot.Spec.Kubernetes.Version).To(Equal("2.0.0"))
+		})
+
 		It("should determine that the shoot kubernetes version must be maintained - MaintenanceAutoUpdate set to true", func() {
 			shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = true
 			shoot.Spec.Kubernetes = gardencorev1beta1.Kubernetes{Version: "1.0.1"}
@@ -1322,9 +3117,226 @@ var _ = Describe("Shoot Maintenance", func() {
 			Expect(shoot.Spec.Provider.Workers[1].Maximum).To(Equal(int32(2)))
 		})
 	})
+
+	Describe("#MaintainWorkerPoolKubeletSettings", func() {
+		var pool *gardencorev1beta1.Worker
+
+		BeforeEach(func() {
+			pool = &gardencorev1beta1.Worker{
+				Name: "cpu-worker",
+				Kubernetes: &gardencorev1beta1.WorkerKubernetes{
+					Kubelet: &gardencorev1beta1.KubeletConfig{
+						KubernetesConfig: gardencorev1beta1.KubernetesConfig{
+							FeatureGates: map[string]bool{
+								// removed in Kubernetes 1.25
+								"WindowsEndpointSliceProxying": true,
+								"AnyVolumeDataSource":          true,
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should do nothing for pools without a kubelet configuration", func() {
+			pool.Kubernetes.Kubelet = nil
+			Expect(maintainWorkerPoolKubeletSettings(pool, semver.MustParse("1.25.0"))).To(BeEmpty())
+		})
+
+		It("should clear feature gates which are no longer supported in the new version", func() {
+			result := maintainWorkerPoolKubeletSettings(pool, semver.MustParse("1.25.0"))
+			Expect(result).To(ConsistOf(ContainSubstring(`Removed kubelet feature gate "WindowsEndpointSliceProxying" of worker pool "cpu-worker"`)))
+			Expect(pool.Kubernetes.Kubelet.FeatureGates).To(Equal(map[string]bool{"AnyVolumeDataSource": true}))
+		})
+
+		It("should keep feature gates which are still supported in the new version", func() {
+			result := maintainWorkerPoolKubeletSettings(pool, semver.MustParse("1.24.0"))
+			Expect(result).To(BeEmpty())
+			Expect(pool.Kubernetes.Kubelet.FeatureGates).To(HaveLen(2))
+		})
+
+		It("should leave unknown feature gates to the validation", func() {
+			pool.Kubernetes.Kubelet.FeatureGates = map[string]bool{"Foo": true}
+			result := maintainWorkerPoolKubeletSettings(pool, semver.MustParse("1.25.0"))
+			Expect(result).To(BeEmpty())
+			Expect(pool.Kubernetes.Kubelet.FeatureGates).To(HaveKey("Foo"))
+		})
+	})
+
+	Describe("#migrateFeatureGates", func() {
+		var shoot *gardencorev1beta1.Shoot
+
+		BeforeEach(func() {
+			shoot = &gardencorev1beta1.Shoot{
+				Spec: gardencorev1beta1.ShootSpec{
+					Kubernetes: gardencorev1beta1.Kubernetes{
+						KubeAPIServer: &gardencorev1beta1.KubeAPIServerConfig{
+							KubernetesConfig: gardencorev1beta1.KubernetesConfig{
+								FeatureGates: map[string]bool{"UserNamespacesStatelessPodsSupport": true, "Foo": true},
+							},
+						},
+						Kubelet: &gardencorev1beta1.KubeletConfig{
+							KubernetesConfig: gardencorev1beta1.KubernetesConfig{
+								FeatureGates: map[string]bool{"DynamicKubeletConfig": false},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should rename a deprecated feature gate when the update crosses the relevant minor", func() {
+			reasons := migrateFeatureGates(shoot, semver.MustParse("1.27.3"), semver.MustParse("1.28.0"))
+
+			Expect(shoot.Spec.Kubernetes.KubeAPIServer.FeatureGates).To(Equal(map[string]bool{"UserNamespacesSupport": true, "Foo": true}))
+			Expect(reasons).To(ConsistOf(ContainSubstring(`Renamed feature gate "UserNamespacesStatelessPodsSupport" of kube-apiserver to "UserNamespacesSupport"`)))
+		})
+
+		It("should remove a feature gate which was removed without replacement", func() {
+			reasons := migrateFeatureGates(shoot, semver.MustParse("1.25.8"), semver.MustParse("1.26.0"))
+
+			Expect(shoot.Spec.Kubernetes.Kubelet.FeatureGates).To(BeEmpty())
+			Expect(reasons).To(ConsistOf(ContainSubstring(`Removed feature gate "DynamicKubeletConfig" of kubelet`)))
+		})
+
+		It("should not rewrite feature gates when the update does not cross the relevant minor", func() {
+			reasons := migrateFeatureGates(shoot, semver.MustParse("1.26.0"), semver.MustParse("1.27.0"))
+
+			Expect(reasons).To(BeEmpty())
+			Expect(shoot.Spec.Kubernetes.KubeAPIServer.FeatureGates).To(HaveKey("UserNamespacesStatelessPodsSupport"))
+			Expect(shoot.Spec.Kubernetes.Kubelet.FeatureGates).To(HaveKey("DynamicKubeletConfig"))
+		})
+	})
+
+	Describe("#maintainOperation", func() {
+		var shoot *gardencorev1beta1.Shoot
+
+		BeforeEach(func() {
+			shoot = &gardencorev1beta1.Shoot{
+				Status: gardencorev1beta1.ShootStatus{
+					LastOperation: &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+				},
+			}
+		})
+
+		It("should promote the kubeconfig credentials rotation operation", func() {
+			metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerMaintenanceOperation, v1beta1constants.ShootOperationRotateKubeconfigCredentials)
+
+			Expect(maintainOperation(shoot)).To(Equal("rotate-kubeconfig-credentials"))
+			Expect(shoot.Annotations[v1beta1constants.GardenerOperation]).To(Equal("rotate-kubeconfig-credentials"))
+			Expect(shoot.Annotations).NotTo(HaveKey(v1beta1constants.GardenerMaintenanceOperation))
+		})
+
+		It("should promote other valid rotation operations", func() {
+			metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerMaintenanceOperation, v1beta1constants.OperationRotateCAStart)
+
+			Expect(maintainOperation(shoot)).To(Equal("rotate-ca-start"))
+			Expect(shoot.Annotations[v1beta1constants.GardenerOperation]).To(Equal("rotate-ca-start"))
+			Expect(shoot.Annotations).NotTo(HaveKey(v1beta1constants.GardenerMaintenanceOperation))
+		})
+
+		It("should drop unknown operations in favor of a plain reconciliation", func() {
+			metav1.SetMetaDataAnnotation(&shoot.ObjectMeta, v1beta1constants.GardenerMaintenanceOperation, "rotate-everything")
+
+			Expect(maintainOperation(shoot)).To(BeEmpty())
+			Expect(shoot.Annotations[v1beta1constants.GardenerOperation]).To(Equal(v1beta1constants.GardenerOperationReconcile))
+			Expect(shoot.Annotations).NotTo(HaveKey(v1beta1constants.GardenerMaintenanceOperation))
+		})
+	})
+
+	Describe("#ExpiredVersions", func() {
+		var (
+			shoot        *gardencorev1beta1.Shoot
+			cloudProfile *gardencorev1beta1.CloudProfile
+		)
+
+		BeforeEach(func() {
+			var (
+				updateStrategy = gardencorev1beta1.UpdateStrategyMajor
+				expired        = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+				valid          = &metav1.Time{Time: time.Now().Add(24 * time.Hour)}
+			)
+
+			cloudProfile = &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					Kubernetes: gardencorev1beta1.KubernetesSettings{
+						Versions: []gardencorev1beta1.ExpirableVersion{
+							{Version: "1.26.0", ExpirationDate: valid},
+							{Version: "1.25.0", ExpirationDate: expired},
+						},
+					},
+					MachineImages: []gardencorev1beta1.MachineImage{{
+						Name:           "gardenlinux",
+						UpdateStrategy: &updateStrategy,
+						Versions: []gardencorev1beta1.MachineImageVersion{
+							{ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "2.0.0", ExpirationDate: valid}},
+							{ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: "1.0.0", ExpirationDate: expired}},
+						},
+					}},
+				},
+			}
+
+			shoot = &gardencorev1beta1.Shoot{
+				Spec: gardencorev1beta1.ShootSpec{
+					Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.25.0"},
+					Provider: gardencorev1beta1.Provider{Workers: []gardencorev1beta1.Worker{
+						{
+							Name:    "cpu-worker",
+							Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: pointer.String("1.0.0")}},
+						},
+						{
+							Name:       "gpu-worker",
+							Kubernetes: &gardencorev1beta1.WorkerKubernetes{Version: pointer.String("1.26.0")},
+							Machine:    gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "gardenlinux", Version: pointer.String("2.0.0")}},
+						},
+					}},
+				},
+			}
+		})
+
+		It("should return the expired versions across control plane and worker pools", func() {
+			Expect(ExpiredVersions(shoot, cloudProfile)).To(ConsistOf(
+				ExpiredVersion{Version: "1.25.0", Target: "control plane"},
+				ExpiredVersion{Version: "1.0.0", Target: `worker pool "cpu-worker" (machine image "gardenlinux")`},
+			))
+		})
+
+		It("should report worker pool Kubernetes version overrides", func() {
+			shoot.Spec.Kubernetes.Version = "1.26.0"
+			shoot.Spec.Provider.Workers[1].Kubernetes.Version = pointer.String("1.25.0")
+
+			Expect(ExpiredVersions(shoot, cloudProfile)).To(ConsistOf(
+				ExpiredVersion{Version: "1.25.0", Target: `worker pool "gpu-worker" (Kubernetes)`},
+				ExpiredVersion{Version: "1.0.0", Target: `worker pool "cpu-worker" (machine image "gardenlinux")`},
+			))
+		})
+
+		It("should report versions missing in the CloudProfile as expired", func() {
+			shoot.Spec.Kubernetes.Version = "1.24.0"
+
+			Expect(ExpiredVersions(shoot, cloudProfile)).To(ContainElement(
+				ExpiredVersion{Version: "1.24.0", Target: "control plane"},
+			))
+		})
+
+		It("should return nothing when all versions are valid", func() {
+			shoot.Spec.Kubernetes.Version = "1.26.0"
+			shoot.Spec.Provider.Workers[0].Machine.Image.Version = pointer.String("2.0.0")
+
+			Expect(ExpiredVersions(shoot, cloudProfile)).To(BeEmpty())
+		})
+	})
 })
 
 func assertWorkerMachineImageVersion(worker *gardencorev1beta1.Worker, imageName string, imageVersion string) {
 	ExpectWithOffset(1, worker.Machine.Image.Name).To(Equal(imageName))
 	ExpectWithOffset(1, *worker.Machine.Image.Version).To(Equal(imageVersion))
 }
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	return events
+}
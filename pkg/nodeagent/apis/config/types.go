@@ -32,6 +32,9 @@ type NodeAgentConfiguration struct {
 	LogLevel string
 	// LogFormat is the output format for the logs. Must be one of [text,json].
 	LogFormat string
+	// StateDir is the directory on the worker node from which all gardener-node-agent state file paths are derived
+	// (e.g., the last applied operating system config). Defaults to "/var/lib/gardener-node-agent".
+	StateDir string
 	// Server defines the configuration of the HTTP server.
 	Server ServerConfiguration
 	// Debugging holds configuration for Debugging related features.
@@ -82,9 +85,30 @@ type OperatingSystemConfigControllerConfig struct {
 	// SecretName defines the name of the secret in the shoot cluster control plane, which contains the operating system
 	// config (OSC) for the gardener-node-agent.
 	SecretName string
+	// AdditionalSecretNames contains names of additional secrets in the shoot cluster control plane containing
+	// operating system configs which are merged into the main operating system config. Files and units of later secrets
+	// override entries of earlier ones with the same path or name.
+	AdditionalSecretNames []string
 	// KubernetesVersion contains the Kubernetes version of the kubelet, used for annotating the corresponding node
 	// resource with a kubernetes version annotation.
 	KubernetesVersion *semver.Version
+	// DeferUnitRestarts specifies whether disruptive unit restarts caused by operating system config changes are
+	// deferred until restarts are explicitly allowed for the node via the "node.gardener.cloud/restart-allowed"
+	// annotation (usually set during maintenance). Pending restarts are persisted across reconciliations.
+	DeferUnitRestarts bool
+	// NodeInventoryAnnotations specifies whether the node is additionally annotated with inventory information
+	// detected from its status, currently the container runtime version and the kernel version.
+	NodeInventoryAnnotations bool
+	// VerifyUnits specifies whether new or changed systemd unit content is validated with `systemd-analyze verify`
+	// before it is written to disk. Units failing the validation are refused.
+	VerifyUnits bool
+	// RollbackOnFailure specifies whether the last successfully applied operating system config is restored when
+	// applying a new one fails partway, so that the node is kept in a consistent state.
+	RollbackOnFailure bool
+	// SummarizeActionLogs specifies whether per-item logs (e.g. one line per written file or restarted unit) are only
+	// emitted at a higher verbosity and replaced by a single summary line, so that large reconciles do not flood the
+	// journal.
+	SummarizeActionLogs bool
 }
 
 // TokenControllerConfig defines the configuration of the access token controller.
@@ -36,6 +36,9 @@ func SetDefaults_NodeAgentConfiguration(obj *NodeAgentConfiguration) {
 	if obj.LogFormat == "" {
 		obj.LogFormat = logger.FormatJSON
 	}
+	if obj.StateDir == "" {
+		obj.StateDir = BaseDir
+	}
 }
 
 // SetDefaults_OperatingSystemConfigControllerConfig sets defaults for the OperatingSystemConfigControllerConfig object.
@@ -39,21 +39,25 @@ var _ = Describe("Defaults", func() {
 
 			Expect(obj.LogLevel).To(Equal(logger.InfoLevel))
 			Expect(obj.LogFormat).To(Equal(logger.FormatJSON))
+			Expect(obj.StateDir).To(Equal(BaseDir))
 		})
 
 		It("should not overwrite custom settings", func() {
 			var (
 				expectedLogLevel  = "foo"
 				expectedLogFormat = "bar"
+				expectedStateDir  = "/data/gardener-node-agent"
 			)
 
 			obj.LogLevel = expectedLogLevel
 			obj.LogFormat = expectedLogFormat
+			obj.StateDir = expectedStateDir
 
 			SetObjectDefaults_NodeAgentConfiguration(obj)
 
 			Expect(obj.LogLevel).To(Equal(expectedLogLevel))
 			Expect(obj.LogFormat).To(Equal(expectedLogFormat))
+			Expect(obj.StateDir).To(Equal(expectedStateDir))
 		})
 
 		Describe("Controller configuration", func() {
@@ -53,6 +53,10 @@ type NodeAgentConfiguration struct {
 	LogLevel string `json:"logLevel"`
 	// LogFormat is the output format for the logs. Must be one of [text,json].
 	LogFormat string `json:"logFormat"`
+	// StateDir is the directory on the worker node from which all gardener-node-agent state file paths are derived
+	// (e.g., the last applied operating system config). Defaults to "/var/lib/gardener-node-agent".
+	// +optional
+	StateDir string `json:"stateDir,omitempty"`
 	// Server defines the configuration of the HTTP server.
 	Server ServerConfiguration `json:"server"`
 	// Debugging holds configuration for Debugging related features.
@@ -109,9 +113,36 @@ type OperatingSystemConfigControllerConfig struct {
 	// SecretName defines the name of the secret in the shoot cluster control plane, which contains the operating system
 	// config (OSC) for the gardener-node-agent.
 	SecretName string `json:"secretName"`
+	// AdditionalSecretNames contains names of additional secrets in the shoot cluster control plane containing
+	// operating system configs which are merged into the main operating system config. Files and units of later secrets
+	// override entries of earlier ones with the same path or name.
+	// +optional
+	AdditionalSecretNames []string `json:"additionalSecretNames,omitempty"`
 	// KubernetesVersion contains the Kubernetes version of the kubelet, used for annotating the corresponding node
 	// resource with a kubernetes version annotation.
 	KubernetesVersion *semver.Version `json:"kubernetesVersion"`
+	// DeferUnitRestarts specifies whether disruptive unit restarts caused by operating system config changes are
+	// deferred until restarts are explicitly allowed for the node via the "node.gardener.cloud/restart-allowed"
+	// annotation (usually set during maintenance). Pending restarts are persisted across reconciliations.
+	// +optional
+	DeferUnitRestarts bool `json:"deferUnitRestarts,omitempty"`
+	// NodeInventoryAnnotations specifies whether the node is additionally annotated with inventory information
+	// detected from its status, currently the container runtime version and the kernel version.
+	// +optional
+	NodeInventoryAnnotations bool `json:"nodeInventoryAnnotations,omitempty"`
+	// VerifyUnits specifies whether new or changed systemd unit content is validated with `systemd-analyze verify`
+	// before it is written to disk. Units failing the validation are refused.
+	// +optional
+	VerifyUnits bool `json:"verifyUnits,omitempty"`
+	// RollbackOnFailure specifies whether the last successfully applied operating system config is restored when
+	// applying a new one fails partway, so that the node is kept in a consistent state.
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+	// SummarizeActionLogs specifies whether per-item logs (e.g. one line per written file or restarted unit) are only
+	// emitted at a higher verbosity and replaced by a single summary line, so that large reconciles do not flood the
+	// journal.
+	// +optional
+	SummarizeActionLogs bool `json:"summarizeActionLogs,omitempty"`
 }
 
 // TokenControllerConfig defines the configuration of the access token controller.
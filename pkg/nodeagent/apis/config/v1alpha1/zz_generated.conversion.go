@@ -201,6 +201,7 @@ func autoConvert_v1alpha1_NodeAgentConfiguration_To_config_NodeAgentConfiguratio
 	}
 	out.LogLevel = in.LogLevel
 	out.LogFormat = in.LogFormat
+	out.StateDir = in.StateDir
 	if err := Convert_v1alpha1_ServerConfiguration_To_config_ServerConfiguration(&in.Server, &out.Server, s); err != nil {
 		return err
 	}
@@ -235,6 +236,7 @@ func autoConvert_config_NodeAgentConfiguration_To_v1alpha1_NodeAgentConfiguratio
 	}
 	out.LogLevel = in.LogLevel
 	out.LogFormat = in.LogFormat
+	out.StateDir = in.StateDir
 	if err := Convert_config_ServerConfiguration_To_v1alpha1_ServerConfiguration(&in.Server, &out.Server, s); err != nil {
 		return err
 	}
@@ -267,7 +269,13 @@ func autoConvert_v1alpha1_OperatingSystemConfigControllerConfig_To_config_Operat
 	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
 	out.SyncJitterPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncJitterPeriod))
 	out.SecretName = in.SecretName
+	out.AdditionalSecretNames = *(*[]string)(unsafe.Pointer(&in.AdditionalSecretNames))
 	out.KubernetesVersion = (*v3.Version)(unsafe.Pointer(in.KubernetesVersion))
+	out.DeferUnitRestarts = in.DeferUnitRestarts
+	out.NodeInventoryAnnotations = in.NodeInventoryAnnotations
+	out.VerifyUnits = in.VerifyUnits
+	out.RollbackOnFailure = in.RollbackOnFailure
+	out.SummarizeActionLogs = in.SummarizeActionLogs
 	return nil
 }
 
@@ -280,7 +288,13 @@ func autoConvert_config_OperatingSystemConfigControllerConfig_To_v1alpha1_Operat
 	out.SyncPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncPeriod))
 	out.SyncJitterPeriod = (*v1.Duration)(unsafe.Pointer(in.SyncJitterPeriod))
 	out.SecretName = in.SecretName
+	out.AdditionalSecretNames = *(*[]string)(unsafe.Pointer(&in.AdditionalSecretNames))
 	out.KubernetesVersion = (*v3.Version)(unsafe.Pointer(in.KubernetesVersion))
+	out.DeferUnitRestarts = in.DeferUnitRestarts
+	out.NodeInventoryAnnotations = in.NodeInventoryAnnotations
+	out.VerifyUnits = in.VerifyUnits
+	out.RollbackOnFailure = in.RollbackOnFailure
+	out.SummarizeActionLogs = in.SummarizeActionLogs
 	return nil
 }
 
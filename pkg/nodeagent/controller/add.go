@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/gardener/gardener/pkg/nodeagent/apis/config"
@@ -27,14 +28,16 @@ import (
 )
 
 // AddToManager adds all controllers to the given manager.
-func AddToManager(cancel context.CancelFunc, mgr manager.Manager, cfg *config.NodeAgentConfiguration, nodeName string) error {
+func AddToManager(cancel context.CancelFunc, mgr manager.Manager, cfg *config.NodeAgentConfiguration, nodeName string, fs afero.Afero) error {
 	if err := (&node.Reconciler{}).AddToManager(mgr); err != nil {
 		return fmt.Errorf("failed adding node controller: %w", err)
 	}
 
 	if err := (&operatingsystemconfig.Reconciler{
 		Config:        cfg.Controllers.OperatingSystemConfig,
+		FS:            fs,
 		NodeName:      nodeName,
+		StateDir:      cfg.StateDir,
 		CancelContext: cancel,
 	}).AddToManager(mgr); err != nil {
 		return fmt.Errorf("failed adding operating system config controller: %w", err)
@@ -42,6 +45,7 @@ func AddToManager(cancel context.CancelFunc, mgr manager.Manager, cfg *config.No
 
 	if err := (&token.Reconciler{
 		Config: cfg.Controllers.Token,
+		FS:     fs,
 	}).AddToManager(mgr); err != nil {
 		return fmt.Errorf("failed adding token controller: %w", err)
 	}
@@ -17,6 +17,7 @@ package operatingsystemconfig
 import (
 	"bytes"
 	"context"
+	"slices"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/afero"
@@ -24,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -35,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	predicateutils "github.com/gardener/gardener/pkg/controllerutils/predicate"
+	nodeagentv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
 	"github.com/gardener/gardener/pkg/nodeagent/dbus"
 	"github.com/gardener/gardener/pkg/nodeagent/registry"
 	"github.com/gardener/gardener/pkg/utils"
@@ -51,15 +54,38 @@ func (r *Reconciler) AddToManager(mgr manager.Manager) error {
 	if r.Recorder == nil {
 		r.Recorder = mgr.GetEventRecorderFor(ControllerName)
 	}
+	if r.Clock == nil {
+		r.Clock = clock.RealClock{}
+	}
 	if r.DBus == nil {
 		r.DBus = dbus.New()
 	}
 	if r.FS.Fs == nil {
 		r.FS = afero.Afero{Fs: afero.NewOsFs()}
 	}
+	if r.StateDir == "" {
+		r.StateDir = nodeagentv1alpha1.BaseDir
+	}
 	if r.Extractor == nil {
 		r.Extractor = registry.NewExtractor()
 	}
+	if r.UnitVerifier == nil && r.Config.VerifyUnits {
+		r.UnitVerifier = SystemdAnalyzeVerifier{}
+	}
+	if r.FileLabeler == nil {
+		r.FileLabeler = ChconFileLabeler{}
+	}
+	if r.LastSuccessfulReconcileTime == nil {
+		r.LastSuccessfulReconcileTime = &LastSuccessfulReconcileTime{}
+	}
+
+	healthChecker := NewHealthChecker(r.Clock, r.LastSuccessfulReconcileTime, 2*r.Config.SyncPeriod.Duration)
+	if err := mgr.AddHealthzCheck(ControllerName+"-last-applied", healthChecker); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck(ControllerName+"-last-applied", healthChecker); err != nil {
+		return err
+	}
 
 	return builder.
 		ControllerManagedBy(mgr).
@@ -69,7 +95,8 @@ func (r *Reconciler) AddToManager(mgr manager.Manager) error {
 			r.EnqueueWithJitterDelay(mgr.GetLogger().WithValues("controller", ControllerName).WithName("jitterEventHandler")),
 			builder.WithPredicates(
 				predicate.NewPredicateFuncs(func(obj client.Object) bool {
-					return obj.GetNamespace() == metav1.NamespaceSystem && obj.GetName() == r.Config.SecretName
+					return obj.GetNamespace() == metav1.NamespaceSystem &&
+						(obj.GetName() == r.Config.SecretName || slices.Contains(r.Config.AdditionalSecretNames, obj.GetName()))
 				}),
 				r.SecretPredicate(),
 				predicateutils.ForEventTypes(predicateutils.Create, predicateutils.Update),
@@ -101,9 +128,16 @@ func (r *Reconciler) SecretPredicate() predicate.Predicate {
 	}
 }
 
-func reconcileRequest(obj client.Object) reconcile.Request {
+func (r *Reconciler) reconcileRequest(obj client.Object) reconcile.Request {
+	name := obj.GetName()
+	// events for additional OSC secrets trigger a reconciliation of the main OSC secret since the reconciler merges
+	// all configured secrets in every reconciliation
+	if slices.Contains(r.Config.AdditionalSecretNames, name) {
+		name = r.Config.SecretName
+	}
+
 	return reconcile.Request{NamespacedName: types.NamespacedName{
-		Name:      obj.GetName(),
+		Name:      name,
 		Namespace: obj.GetNamespace(),
 	}}
 }
@@ -119,7 +153,7 @@ func (r *Reconciler) EnqueueWithJitterDelay(log logr.Logger) handler.EventHandle
 			if evt.Object == nil {
 				return
 			}
-			q.Add(reconcileRequest(evt.Object))
+			q.Add(r.reconcileRequest(evt.Object))
 		},
 
 		UpdateFunc: func(_ context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
@@ -135,7 +169,7 @@ func (r *Reconciler) EnqueueWithJitterDelay(log logr.Logger) handler.EventHandle
 			if !bytes.Equal(oldSecret.Data[dataKeyOperatingSystemConfig], newSecret.Data[dataKeyOperatingSystemConfig]) {
 				duration := RandomDurationWithMetaDuration(r.Config.SyncJitterPeriod)
 				log.Info("Enqueued secret with operating system config with a jitter period", "duration", duration)
-				q.AddAfter(reconcileRequest(evt.ObjectNew), duration)
+				q.AddAfter(r.reconcileRequest(evt.ObjectNew), duration)
 			}
 		},
 	}
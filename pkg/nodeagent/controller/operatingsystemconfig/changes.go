@@ -18,24 +18,34 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils"
 )
 
-var decoder runtime.Decoder
+var (
+	decoder runtime.Decoder
+	codec   runtime.Codec
+)
 
 func init() {
 	scheme := runtime.NewScheme()
 	utilruntime.Must(extensionsv1alpha1.AddToScheme(scheme))
 	decoder = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	yamlSerializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+	versions := schema.GroupVersions([]schema.GroupVersion{extensionsv1alpha1.SchemeGroupVersion})
+	codec = serializer.NewCodecFactory(scheme).CodecForVersions(yamlSerializer, yamlSerializer, versions, versions)
 }
 
 func extractOSCFromSecret(secret *corev1.Secret) (*extensionsv1alpha1.OperatingSystemConfig, []byte, string, error) {
@@ -52,6 +62,54 @@ func extractOSCFromSecret(secret *corev1.Secret) (*extensionsv1alpha1.OperatingS
 	return osc, oscRaw, utils.ComputeSHA256Hex(oscRaw), nil
 }
 
+// mergeOperatingSystemConfigs merges the additional OSC into the base OSC. Files and units of the additional OSC
+// override entries of the base OSC with the same path or name, all other entries are appended. Hence, the merge is
+// deterministic and later OSCs take precedence over earlier ones.
+func mergeOperatingSystemConfigs(base, additional *extensionsv1alpha1.OperatingSystemConfig) *extensionsv1alpha1.OperatingSystemConfig {
+	merged := base.DeepCopy()
+	merged.Spec.Files = mergeFileLists(merged.Spec.Files, additional.Spec.Files)
+	merged.Spec.Units = mergeUnitLists(merged.Spec.Units, additional.Spec.Units)
+	merged.Status.ExtensionFiles = mergeFileLists(merged.Status.ExtensionFiles, additional.Status.ExtensionFiles)
+	merged.Status.ExtensionUnits = mergeUnitLists(merged.Status.ExtensionUnits, additional.Status.ExtensionUnits)
+	return merged
+}
+
+func mergeFileLists(base, overriding []extensionsv1alpha1.File) []extensionsv1alpha1.File {
+	out := slices.Clone(base)
+
+	for _, file := range overriding {
+		fileIndex := slices.IndexFunc(out, func(existingFile extensionsv1alpha1.File) bool {
+			return existingFile.Path == file.Path
+		})
+
+		if fileIndex == -1 {
+			out = append(out, file)
+			continue
+		}
+		out[fileIndex] = file
+	}
+
+	return out
+}
+
+func mergeUnitLists(base, overriding []extensionsv1alpha1.Unit) []extensionsv1alpha1.Unit {
+	out := slices.Clone(base)
+
+	for _, unit := range overriding {
+		unitIndex := slices.IndexFunc(out, func(existingUnit extensionsv1alpha1.Unit) bool {
+			return existingUnit.Name == unit.Name
+		})
+
+		if unitIndex == -1 {
+			out = append(out, unit)
+			continue
+		}
+		out[unitIndex] = unit
+	}
+
+	return out
+}
+
 type operatingSystemConfigChanges struct {
 	units units
 	files files
@@ -77,7 +135,13 @@ type files struct {
 	deleted []extensionsv1alpha1.File
 }
 
-func computeOperatingSystemConfigChanges(fs afero.Afero, newOSC *extensionsv1alpha1.OperatingSystemConfig) (*operatingSystemConfigChanges, error) {
+func computeOperatingSystemConfigChanges(fs afero.Afero, newOSC *extensionsv1alpha1.OperatingSystemConfig, lastAppliedOperatingSystemConfigFilePath string) (*operatingSystemConfigChanges, error) {
+	// fail before anything is written to disk if multiple owners declare the same file path with different content,
+	// as the resulting node state would depend on the application order otherwise
+	if err := detectFilePathConflicts(newOSC); err != nil {
+		return nil, err
+	}
+
 	changes := &operatingSystemConfigChanges{}
 
 	// osc.files and osc.unit.files should be changed the same way by OSC controller.
@@ -221,6 +285,9 @@ func mergeUnits(specUnits, statusUnits []extensionsv1alpha1.Unit) []extensionsv1
 		if unit.Content != nil {
 			out[unitIndex].Content = unit.Content
 		}
+		if unit.ReloadInsteadOfRestart != nil {
+			out[unitIndex].ReloadInsteadOfRestart = unit.ReloadInsteadOfRestart
+		}
 		out[unitIndex].DropIns = append(out[unitIndex].DropIns, unit.DropIns...)
 		out[unitIndex].Files = append(out[unitIndex].Files, unit.Files...)
 	}
@@ -228,6 +295,63 @@ func mergeUnits(specUnits, statusUnits []extensionsv1alpha1.Unit) []extensionsv1
 	return out
 }
 
+// detectFilePathConflicts returns an error listing all file paths which are declared by multiple owners (units,
+// osc.spec.files or osc.status.extensionFiles) with different content.
+func detectFilePathConflicts(osc *extensionsv1alpha1.OperatingSystemConfig) error {
+	type declaration struct {
+		owner string
+		file  extensionsv1alpha1.File
+	}
+
+	declarationsPerPath := map[string][]declaration{}
+
+	addFiles := func(owner string, files []extensionsv1alpha1.File) {
+		for _, file := range files {
+			declarationsPerPath[file.Path] = append(declarationsPerPath[file.Path], declaration{owner: owner, file: file})
+		}
+	}
+
+	addFiles("osc.spec.files", osc.Spec.Files)
+	addFiles("osc.status.extensionFiles", osc.Status.ExtensionFiles)
+	for _, unit := range mergeUnits(osc.Spec.Units, osc.Status.ExtensionUnits) {
+		addFiles(fmt.Sprintf("unit %q", unit.Name), unit.Files)
+	}
+
+	filePaths := make([]string, 0, len(declarationsPerPath))
+	for filePath := range declarationsPerPath {
+		filePaths = append(filePaths, filePath)
+	}
+	slices.Sort(filePaths)
+
+	var conflicts []string
+
+	for _, filePath := range filePaths {
+		declarations := declarationsPerPath[filePath]
+		if len(declarations) < 2 {
+			continue
+		}
+
+		conflicting := false
+		owners := make([]string, 0, len(declarations))
+		for _, d := range declarations {
+			owners = append(owners, d.owner)
+			if !apiequality.Semantic.DeepEqual(d.file, declarations[0].file) {
+				conflicting = true
+			}
+		}
+
+		if conflicting {
+			conflicts = append(conflicts, fmt.Sprintf("file %q is declared by multiple owners (%s) with different content", filePath, strings.Join(owners, ", ")))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("operating system config contains conflicting file declarations: %s", strings.Join(conflicts, "; "))
+	}
+
+	return nil
+}
+
 func collectUnitFiles(units []extensionsv1alpha1.Unit) []extensionsv1alpha1.File {
 	var unitFiles []extensionsv1alpha1.File
 
@@ -0,0 +1,40 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// FileLabeler applies SELinux security context labels to files so that services on SELinux-enforcing nodes can
+// access them.
+type FileLabeler interface {
+	// ApplyContext applies the given SELinux context label to the file at the given path.
+	ApplyContext(ctx context.Context, path, seLinuxContext string) error
+}
+
+// ChconFileLabeler applies SELinux context labels by calling the chcon binary. Applying an already present label is
+// a no-op for chcon, so repeated invocations are idempotent.
+type ChconFileLabeler struct{}
+
+// ApplyContext runs `chcon <seLinuxContext> <path>`.
+func (ChconFileLabeler) ApplyContext(ctx context.Context, path, seLinuxContext string) error {
+	if output, err := exec.CommandContext(ctx, "chcon", seLinuxContext, path).CombinedOutput(); err != nil {
+		return fmt.Errorf("chcon failed for file %q: %s: %w", path, string(output), err)
+	}
+	return nil
+}
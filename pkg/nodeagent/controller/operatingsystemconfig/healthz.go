@@ -0,0 +1,65 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// LastSuccessfulReconcileTime is a thread-safe holder for the time of the last successful reconciliation. It is shared
+// between the Reconciler and the health checkers registered on the manager.
+type LastSuccessfulReconcileTime struct {
+	mutex sync.RWMutex
+	time  time.Time
+}
+
+// Set records the given time as the time of the last successful reconciliation.
+func (l *LastSuccessfulReconcileTime) Set(t time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.time = t
+}
+
+// Get returns the time of the last successful reconciliation. It returns the zero time if no reconciliation succeeded
+// yet.
+func (l *LastSuccessfulReconcileTime) Get() time.Time {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.time
+}
+
+// NewHealthChecker returns a healthz.Checker which fails when the last successful application of an operating system
+// config is older than the given threshold. As long as no reconciliation succeeded yet, the checker reports success in
+// order to not fail during start-up of the gardener-node-agent.
+func NewHealthChecker(clock clock.Clock, lastSuccessfulReconcile *LastSuccessfulReconcileTime, threshold time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		lastSuccess := lastSuccessfulReconcile.Get()
+		if lastSuccess.IsZero() {
+			return nil
+		}
+
+		if age := clock.Now().Sub(lastSuccess); age > threshold {
+			return fmt.Errorf("last successful operating system config application is too old (%s > %s)", age, threshold)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	testclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+)
+
+var _ = Describe("Healthz", func() {
+	Describe("#NewHealthChecker", func() {
+		var (
+			fakeClock               *testclock.FakeClock
+			lastSuccessfulReconcile *LastSuccessfulReconcileTime
+			checker                 healthz.Checker
+
+			threshold = 2 * time.Hour
+		)
+
+		BeforeEach(func() {
+			fakeClock = testclock.NewFakeClock(time.Now())
+			lastSuccessfulReconcile = &LastSuccessfulReconcileTime{}
+			checker = NewHealthChecker(fakeClock, lastSuccessfulReconcile, threshold)
+		})
+
+		It("should succeed when no reconciliation succeeded yet", func() {
+			Expect(checker(nil)).To(Succeed())
+		})
+
+		It("should succeed when the last successful reconciliation is recent enough", func() {
+			lastSuccessfulReconcile.Set(fakeClock.Now())
+			fakeClock.Step(threshold)
+
+			Expect(checker(nil)).To(Succeed())
+		})
+
+		It("should fail when the last successful reconciliation is older than the threshold", func() {
+			lastSuccessfulReconcile.Set(fakeClock.Now())
+
+			Expect(checker(nil)).To(Succeed())
+
+			fakeClock.Step(threshold + time.Second)
+
+			Expect(checker(nil)).To(MatchError(ContainSubstring("last successful operating system config application is too old")))
+		})
+	})
+})
@@ -17,12 +17,15 @@ package operatingsystemconfig
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,8 +33,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -45,31 +51,92 @@ import (
 	"github.com/gardener/gardener/pkg/nodeagent/apis/config"
 	nodeagentv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
 	"github.com/gardener/gardener/pkg/nodeagent/dbus"
+	"github.com/gardener/gardener/pkg/nodeagent/metrics"
 	"github.com/gardener/gardener/pkg/nodeagent/registry"
+	"github.com/gardener/gardener/pkg/utils"
 	"github.com/gardener/gardener/pkg/utils/flow"
 )
 
 const (
 	dataKeyOperatingSystemConfig             = "osc.yaml"
-	lastAppliedOperatingSystemConfigFilePath = nodeagentv1alpha1.BaseDir + "/last-applied-osc.yaml"
+	lastAppliedOperatingSystemConfigFileName = "last-applied-osc.yaml"
+	pendingUnitRestartsFileName              = "pending-unit-restarts.json"
+	pausedFileName                           = "paused"
+)
+
+// PausedConditionType is the type of the Node condition indicating whether reconciliation of operating system configs
+// is paused because the pause file exists on the node.
+const PausedConditionType corev1.NodeConditionType = "GardenerNodeAgentPaused"
+
+// AnnotationRestartAllowed is an annotation on the Node object allowing the execution of deferred disruptive unit
+// restarts. It is usually set during the maintenance of the node.
+const AnnotationRestartAllowed = "node.gardener.cloud/restart-allowed"
+
+const (
+	// AnnotationContainerRuntimeVersion is an annotation on the Node object describing the container runtime version
+	// detected by the kubelet. It is only maintained when inventory annotations are enabled in the controller config.
+	AnnotationContainerRuntimeVersion = "worker.gardener.cloud/container-runtime-version"
+	// AnnotationKernelVersion is an annotation on the Node object describing the kernel version detected by the
+	// kubelet. It is only maintained when inventory annotations are enabled in the controller config.
+	AnnotationKernelVersion = "worker.gardener.cloud/kernel-version"
 )
 
 // Reconciler decodes the OperatingSystemConfig resources from secrets and applies the systemd units and files to the
 // node.
 type Reconciler struct {
-	Client        client.Client
-	Config        config.OperatingSystemConfigControllerConfig
-	Recorder      record.EventRecorder
-	DBus          dbus.DBus
-	FS            afero.Afero
-	NodeName      string
-	Extractor     registry.Extractor
-	CancelContext context.CancelFunc
+	Client   client.Client
+	Config   config.OperatingSystemConfigControllerConfig
+	Recorder record.EventRecorder
+	Clock    clock.Clock
+	DBus     dbus.DBus
+	FS       afero.Afero
+	NodeName string
+	// StateDir is the directory from which all state file paths of the reconciler are derived. If unset, it defaults
+	// to nodeagentv1alpha1.BaseDir.
+	StateDir  string
+	Extractor registry.Extractor
+	// UnitVerifier validates new or changed unit content before it is written to disk. If nil, no validation takes
+	// place.
+	UnitVerifier UnitVerifier
+	// FileLabeler applies SELinux context labels to written files. If nil, no labels are applied.
+	FileLabeler                 FileLabeler
+	CancelContext               context.CancelFunc
+	LastSuccessfulReconcileTime *LastSuccessfulReconcileTime
+}
+
+// lastAppliedOperatingSystemConfigFilePath returns the path of the file in which the last applied operating system
+// config is persisted.
+func (r *Reconciler) lastAppliedOperatingSystemConfigFilePath() string {
+	return path.Join(r.StateDir, lastAppliedOperatingSystemConfigFileName)
+}
+
+// pendingUnitRestartsFilePath returns the path of the file in which deferred unit restarts are persisted.
+func (r *Reconciler) pendingUnitRestartsFilePath() string {
+	return path.Join(r.StateDir, pendingUnitRestartsFileName)
+}
+
+// pausedFilePath returns the path of the sentinel file which human operators can create to stop the reconciler from
+// reverting manual changes while debugging a node. Reconciliation resumes as soon as the file is removed.
+func (r *Reconciler) pausedFilePath() string {
+	return path.Join(r.StateDir, pausedFileName)
 }
 
 // Reconcile decodes the OperatingSystemConfig resources from secrets and applies the systemd units and files to the
 // node.
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reconcileStart := time.Now()
+
+	result, err := r.reconcile(ctx, request)
+
+	metrics.ReconcileDuration.Observe(time.Since(reconcileStart).Seconds())
+	if err != nil {
+		metrics.ReconcileErrors.Inc()
+	}
+
+	return result, err
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, err error) {
 	log := logf.FromContext(ctx)
 
 	ctx, cancel := controllerutils.GetMainReconciliationContext(ctx, controllerutils.DefaultReconciliationTimeout)
@@ -93,50 +160,58 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		node = nil
 	}
 
-	osc, oscRaw, oscChecksum, err := extractOSCFromSecret(secret)
+	defer func() {
+		if err != nil && node != nil {
+			r.Recorder.Eventf(node, corev1.EventTypeWarning, "OSCReconcileFailed", "Reconciliation of operating system config failed: %v", err)
+		}
+	}()
+
+	paused, err := r.FS.Exists(r.pausedFilePath())
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, fmt.Errorf("unable to check whether pause file %q exists: %w", r.pausedFilePath(), err)
 	}
 
-	oscChanges, err := computeOperatingSystemConfigChanges(r.FS, osc)
-	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("unable to calculate the OSC changes: %w", err)
+	if err := r.updatePausedNodeCondition(ctx, node, paused); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed updating the %s node condition: %w", PausedConditionType, err)
 	}
 
-	if node != nil && node.Annotations[executor.AnnotationKeyChecksum] == oscChecksum {
-		log.Info("Configuration on this node is up to date, nothing to be done")
-		return reconcile.Result{}, nil
+	if paused {
+		log.Info("Reconciliation is paused because the pause file exists, skipping all actions - remove the file to resume", "path", r.pausedFilePath())
+		return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, nil
 	}
 
-	log.Info("Applying new or changed files")
-	if err := r.applyChangedFiles(ctx, log, oscChanges.files.changed); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed applying changed files: %w", err)
+	osc, oscRaw, oscChecksum, err := r.extractOperatingSystemConfig(ctx, secret)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
 
-	log.Info("Applying new or changed units")
-	if err := r.applyChangedUnits(ctx, log, oscChanges.units.changed); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed applying changed units: %w", err)
+	oscChanges, err := computeOperatingSystemConfigChanges(r.FS, osc, r.lastAppliedOperatingSystemConfigFilePath())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to calculate the OSC changes: %w", err)
 	}
 
-	log.Info("Removing no longer needed units")
-	if err := r.removeDeletedUnits(ctx, log, node, oscChanges.units.deleted); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed removing deleted units: %w", err)
+	if err := r.executePendingUnitRestarts(ctx, log, node); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed executing pending unit restarts: %w", err)
 	}
 
-	log.Info("Reloading systemd daemon")
-	if err := r.DBus.DaemonReload(ctx); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed reloading systemd daemon: %w", err)
+	if node != nil && node.Annotations[executor.AnnotationKeyChecksum] == oscChecksum {
+		log.Info("Configuration on this node is up to date, nothing to be done")
+		r.LastSuccessfulReconcileTime.Set(r.Clock.Now())
+		return reconcile.Result{}, nil
 	}
 
-	log.Info("Executing unit commands (start/stop)")
-	mustRestartGardenerNodeAgent, err := r.executeUnitCommands(ctx, log, node, oscChanges.units.changed)
+	mustRestartGardenerNodeAgent, err := r.applyOperatingSystemConfig(ctx, log, node, osc, oscChanges)
 	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed executing unit commands: %w", err)
+		if r.Config.RollbackOnFailure {
+			if rollbackErr := r.rollbackToLastAppliedOperatingSystemConfig(ctx, log, node, err); rollbackErr != nil {
+				return reconcile.Result{}, fmt.Errorf("failed rolling back to the last applied OSC: %w (rollback was triggered by apply error: %s)", rollbackErr, err.Error())
+			}
+		}
+		return reconcile.Result{}, err
 	}
 
-	log.Info("Removing no longer needed files")
-	if err := r.removeDeletedFiles(log, oscChanges.files.deleted); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed removing deleted files: %w", err)
+	if err := r.updateRolledBackNodeCondition(ctx, node, nil); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed updating the %s node condition: %w", RolledBackConditionType, err)
 	}
 
 	log.Info("Successfully applied operating system config",
@@ -146,12 +221,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		"deletedUnits", len(oscChanges.units.deleted),
 	)
 
-	log.Info("Persisting current operating system config as 'last-applied' file to the disk", "path", lastAppliedOperatingSystemConfigFilePath)
-	if err := r.FS.WriteFile(lastAppliedOperatingSystemConfigFilePath, oscRaw, 0644); err != nil {
-		return reconcile.Result{}, fmt.Errorf("unable to write current OSC to file path %q: %w", lastAppliedOperatingSystemConfigFilePath, err)
+	lastAppliedFilePath := r.lastAppliedOperatingSystemConfigFilePath()
+	log.Info("Persisting current operating system config as 'last-applied' file to the disk", "path", lastAppliedFilePath)
+	if err := r.FS.WriteFile(lastAppliedFilePath, oscRaw, 0644); err != nil {
+		return reconcile.Result{}, fmt.Errorf("unable to write current OSC to file path %q: %w", lastAppliedFilePath, err)
 	}
 
+	r.LastSuccessfulReconcileTime.Set(r.Clock.Now())
+
 	if mustRestartGardenerNodeAgent {
+		if node != nil {
+			r.Recorder.Event(node, corev1.EventTypeNormal, "SelfRestartRequested", "Restart of gardener-node-agent unit requested since its configuration changed")
+		}
 		log.Info("Must restart myself (gardener-node-agent unit), canceling the context to initiate graceful shutdown")
 		r.CancelContext()
 		return reconcile.Result{}, nil
@@ -167,14 +248,291 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	patch := client.MergeFrom(node.DeepCopy())
 	metav1.SetMetaDataAnnotation(&node.ObjectMeta, v1beta1constants.LabelWorkerKubernetesVersion, r.Config.KubernetesVersion.String())
 	metav1.SetMetaDataAnnotation(&node.ObjectMeta, executor.AnnotationKeyChecksum, oscChecksum)
+	if err := r.setNodeInventoryAnnotations(ctx, node); err != nil {
+		return reconcile.Result{}, err
+	}
 	return reconcile.Result{RequeueAfter: r.Config.SyncPeriod.Duration}, r.Client.Patch(ctx, node, patch)
 }
 
+// applyOperatingSystemConfig applies the given changes of the operating system config to the node. It returns whether
+// the gardener-node-agent unit itself changed and must be restarted.
+// It guarantees a deterministic ordering of the systemd actions: units are first enabled or disabled (in operating
+// system config order), then the systemd daemon is reloaded, and only afterwards units are restarted or stopped (again
+// in operating system config order). Restarting a unit before the daemon-reload would start it with its old definition.
+func (r *Reconciler) applyOperatingSystemConfig(ctx context.Context, log logr.Logger, node *metav1.PartialObjectMetadata, osc *extensionsv1alpha1.OperatingSystemConfig, oscChanges *operatingSystemConfigChanges) (bool, error) {
+	// On nodes with many files and units, logging one line per action can flood the journal. When action log
+	// summarization is enabled, the per-item logs are only emitted at a higher verbosity and a single summary line is
+	// logged instead.
+	itemLog := log
+	if r.Config.SummarizeActionLogs {
+		itemLog = log.V(1)
+	}
+
+	log.Info("Applying new or changed files")
+	if err := r.applyChangedFiles(ctx, itemLog, oscChanges.files.changed); err != nil {
+		return false, fmt.Errorf("failed applying changed files: %w", err)
+	}
+
+	log.Info("Applying sysctl settings")
+	if err := r.applySysctls(ctx, itemLog, node, osc.Spec.Sysctls); err != nil {
+		return false, fmt.Errorf("failed applying sysctl settings: %w", err)
+	}
+
+	log.Info("Applying kernel modules")
+	if err := r.applyKernelModules(ctx, itemLog, node, osc.Spec.KernelModules); err != nil {
+		return false, fmt.Errorf("failed applying kernel modules: %w", err)
+	}
+
+	log.Info("Applying new or changed units")
+	if err := r.applyChangedUnits(ctx, itemLog, oscChanges.units.changed); err != nil {
+		return false, fmt.Errorf("failed applying changed units: %w", err)
+	}
+
+	log.Info("Removing no longer needed units")
+	if err := r.removeDeletedUnits(ctx, itemLog, node, oscChanges.units.deleted); err != nil {
+		return false, fmt.Errorf("failed removing deleted units: %w", err)
+	}
+
+	log.Info("Reloading systemd daemon")
+	if err := r.DBus.DaemonReload(ctx); err != nil {
+		return false, fmt.Errorf("failed reloading systemd daemon: %w", err)
+	}
+
+	log.Info("Executing unit commands (start/stop)")
+	mustRestartGardenerNodeAgent, err := r.executeUnitCommands(ctx, itemLog, node, oscChanges.units.changed)
+	if err != nil {
+		return false, fmt.Errorf("failed executing unit commands: %w", err)
+	}
+
+	log.Info("Removing no longer needed files")
+	if err := r.removeDeletedFiles(itemLog, oscChanges.files.deleted); err != nil {
+		return false, fmt.Errorf("failed removing deleted files: %w", err)
+	}
+
+	if r.Config.SummarizeActionLogs {
+		log.Info("Summary of performed actions", "summary", fmt.Sprintf("wrote %d file(s), removed %d file(s), applied %d unit(s), removed %d unit(s)",
+			len(oscChanges.files.changed), len(oscChanges.files.deleted), len(oscChanges.units.changed), len(oscChanges.units.deleted)))
+	}
+
+	return mustRestartGardenerNodeAgent, nil
+}
+
+// rollbackToLastAppliedOperatingSystemConfig restores the files and units of the last successfully applied operating
+// system config after a failed apply, so that a partially applied OSC does not leave the node in an inconsistent
+// state. The failure and the rollback are recorded in the RolledBackConditionType node condition.
+func (r *Reconciler) rollbackToLastAppliedOperatingSystemConfig(ctx context.Context, log logr.Logger, node *metav1.PartialObjectMetadata, applyErr error) error {
+	lastAppliedFilePath := r.lastAppliedOperatingSystemConfigFilePath()
+
+	lastAppliedRaw, err := r.FS.ReadFile(lastAppliedFilePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) || errors.Is(err, fs.ErrNotExist) {
+			log.Info("No last applied operating system config exists, nothing to roll back to")
+			return nil
+		}
+		return fmt.Errorf("error reading last applied OSC from file path %s: %w", lastAppliedFilePath, err)
+	}
+
+	lastAppliedOSC := &extensionsv1alpha1.OperatingSystemConfig{}
+	if err := runtime.DecodeInto(decoder, lastAppliedRaw, lastAppliedOSC); err != nil {
+		return fmt.Errorf("unable to decode the last applied OSC read from file path %s: %w", lastAppliedFilePath, err)
+	}
+
+	log.Info("Rolling back to the last applied operating system config because applying the new one failed")
+
+	if err := r.applyChangedFiles(ctx, log, collectAllFiles(lastAppliedOSC)); err != nil {
+		return fmt.Errorf("failed restoring files of the last applied OSC: %w", err)
+	}
+
+	var unitChanges []changedUnit
+	for _, unit := range mergeUnits(lastAppliedOSC.Spec.Units, lastAppliedOSC.Status.ExtensionUnits) {
+		unitChanges = append(unitChanges, changedUnit{
+			Unit:    unit,
+			dropIns: dropIns{changed: unit.DropIns},
+		})
+	}
+
+	if err := r.applyChangedUnits(ctx, log, unitChanges); err != nil {
+		return fmt.Errorf("failed restoring units of the last applied OSC: %w", err)
+	}
+
+	if err := r.DBus.DaemonReload(ctx); err != nil {
+		return fmt.Errorf("failed reloading systemd daemon: %w", err)
+	}
+
+	if node != nil {
+		r.Recorder.Eventf(node, corev1.EventTypeWarning, "OSCRolledBack", "Rolled back to the last applied operating system config after a failed apply: %v", applyErr)
+	}
+
+	return r.updateRolledBackNodeCondition(ctx, node, applyErr)
+}
+
+// setNodeInventoryAnnotations annotates the node with the container runtime and kernel version detected by the
+// kubelet, so that the versions actually running in the fleet can be inventoried centrally.
+func (r *Reconciler) setNodeInventoryAnnotations(ctx context.Context, node *metav1.PartialObjectMetadata) error {
+	if !r.Config.NodeInventoryAnnotations {
+		return nil
+	}
+
+	fullNode := &corev1.Node{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(node), fullNode); err != nil {
+		return fmt.Errorf("unable to fetch node %q: %w", node.Name, err)
+	}
+
+	if version := fullNode.Status.NodeInfo.ContainerRuntimeVersion; version != "" {
+		metav1.SetMetaDataAnnotation(&node.ObjectMeta, AnnotationContainerRuntimeVersion, version)
+	}
+
+	if version := fullNode.Status.NodeInfo.KernelVersion; version != "" {
+		metav1.SetMetaDataAnnotation(&node.ObjectMeta, AnnotationKernelVersion, version)
+	}
+
+	return nil
+}
+
+// RolledBackConditionType is the type of the Node condition indicating whether the last applied operating system
+// config was restored after a failed apply.
+const RolledBackConditionType corev1.NodeConditionType = "GardenerNodeAgentRolledBack"
+
+// updateRolledBackNodeCondition maintains a condition on the Node object reflecting whether the last applied operating
+// system config was restored after a failed apply. The condition is only written when its status actually changes so
+// that nodes which never rolled back are not touched.
+func (r *Reconciler) updateRolledBackNodeCondition(ctx context.Context, node *metav1.PartialObjectMetadata, applyErr error) error {
+	if node == nil {
+		return nil
+	}
+
+	fullNode := &corev1.Node{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(node), fullNode); err != nil {
+		return fmt.Errorf("unable to fetch node %q: %w", node.Name, err)
+	}
+
+	var existingCondition *corev1.NodeCondition
+	for i, condition := range fullNode.Status.Conditions {
+		if condition.Type == RolledBackConditionType {
+			existingCondition = &fullNode.Status.Conditions[i]
+			break
+		}
+	}
+
+	desiredStatus, reason, message := corev1.ConditionFalse, "OSCApplied", "The operating system config was applied successfully."
+	if applyErr != nil {
+		desiredStatus, reason, message = corev1.ConditionTrue, "OSCApplyFailed", fmt.Sprintf("Rolled back to the last applied operating system config because applying the new one failed: %v", applyErr)
+	}
+
+	if existingCondition == nil && applyErr == nil {
+		return nil
+	}
+	if existingCondition != nil && existingCondition.Status == desiredStatus {
+		return nil
+	}
+
+	patch := client.StrategicMergeFrom(fullNode.DeepCopy())
+	newCondition := corev1.NodeCondition{
+		Type:               RolledBackConditionType,
+		Status:             desiredStatus,
+		Reason:             reason,
+		Message:            message,
+		LastHeartbeatTime:  metav1.NewTime(r.Clock.Now()),
+		LastTransitionTime: metav1.NewTime(r.Clock.Now()),
+	}
+
+	if existingCondition != nil {
+		*existingCondition = newCondition
+	} else {
+		fullNode.Status.Conditions = append(fullNode.Status.Conditions, newCondition)
+	}
+
+	return r.Client.Status().Patch(ctx, fullNode, patch)
+}
+
+// updatePausedNodeCondition maintains a condition on the Node object reflecting whether reconciliation is paused via
+// the pause file. The condition is only written when its status actually changes so that unpaused nodes without the
+// condition are not touched.
+func (r *Reconciler) updatePausedNodeCondition(ctx context.Context, node *metav1.PartialObjectMetadata, paused bool) error {
+	if node == nil {
+		return nil
+	}
+
+	fullNode := &corev1.Node{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(node), fullNode); err != nil {
+		return fmt.Errorf("unable to fetch node %q: %w", node.Name, err)
+	}
+
+	var existingCondition *corev1.NodeCondition
+	for i, condition := range fullNode.Status.Conditions {
+		if condition.Type == PausedConditionType {
+			existingCondition = &fullNode.Status.Conditions[i]
+			break
+		}
+	}
+
+	desiredStatus, reason, message := corev1.ConditionFalse, "ReconciliationActive", "The gardener-node-agent is applying operating system configs."
+	if paused {
+		desiredStatus, reason, message = corev1.ConditionTrue, "PauseFileExists", fmt.Sprintf("Reconciliation is paused because the pause file %q exists.", r.pausedFilePath())
+	}
+
+	if existingCondition == nil && !paused {
+		return nil
+	}
+	if existingCondition != nil && existingCondition.Status == desiredStatus {
+		return nil
+	}
+
+	patch := client.StrategicMergeFrom(fullNode.DeepCopy())
+	newCondition := corev1.NodeCondition{
+		Type:               PausedConditionType,
+		Status:             desiredStatus,
+		Reason:             reason,
+		Message:            message,
+		LastHeartbeatTime:  metav1.NewTime(r.Clock.Now()),
+		LastTransitionTime: metav1.NewTime(r.Clock.Now()),
+	}
+
+	if existingCondition != nil {
+		*existingCondition = newCondition
+	} else {
+		fullNode.Status.Conditions = append(fullNode.Status.Conditions, newCondition)
+	}
+
+	return r.Client.Status().Patch(ctx, fullNode, patch)
+}
+
 var (
 	etcSystemdSystem                   = path.Join("/", "etc", "systemd", "system")
 	defaultFilePermissions os.FileMode = 0600
 )
 
+// extractOperatingSystemConfig extracts the OSC from the given secret and merges the OSCs of all additionally
+// configured secrets into it. The returned raw data and checksum are computed from the merged OSC so that the checksum
+// annotation on the node covers all layered configs.
+func (r *Reconciler) extractOperatingSystemConfig(ctx context.Context, secret *corev1.Secret) (*extensionsv1alpha1.OperatingSystemConfig, []byte, string, error) {
+	osc, oscRaw, oscChecksum, err := extractOSCFromSecret(secret)
+	if err != nil || len(r.Config.AdditionalSecretNames) == 0 {
+		return osc, oscRaw, oscChecksum, err
+	}
+
+	for _, secretName := range r.Config.AdditionalSecretNames {
+		additionalSecret := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: secret.Namespace, Name: secretName}, additionalSecret); err != nil {
+			return nil, nil, "", fmt.Errorf("unable to fetch additional OSC secret %q: %w", secretName, err)
+		}
+
+		additionalOSC, _, _, err := extractOSCFromSecret(additionalSecret)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("unable to extract OSC from additional secret %q: %w", secretName, err)
+		}
+
+		osc = mergeOperatingSystemConfigs(osc, additionalOSC)
+	}
+
+	mergedRaw, err := runtime.Encode(codec, osc)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("unable to encode merged OSC: %w", err)
+	}
+
+	return osc, mergedRaw, utils.ComputeSHA256Hex(mergedRaw), nil
+}
+
 func (r *Reconciler) applyChangedFiles(ctx context.Context, log logr.Logger, files []extensionsv1alpha1.File) error {
 	tmpDir, err := r.FS.TempDir("", "gardener-node-agent-*")
 	if err != nil {
@@ -199,6 +557,16 @@ func (r *Reconciler) applyChangedFiles(ctx context.Context, log logr.Logger, fil
 				return fmt.Errorf("unable to decode data of file %q: %w", file.Path, err)
 			}
 
+			if file.Content.Inline.Append {
+				if err := r.appendFileContent(log, file.Path, data, permissions); err != nil {
+					return err
+				}
+				if err := r.applySELinuxContext(ctx, file); err != nil {
+					return err
+				}
+				continue
+			}
+
 			tmpFilePath := filepath.Join(tmpDir, filepath.Base(file.Path))
 			if err := r.FS.WriteFile(tmpFilePath, data, permissions); err != nil {
 				return fmt.Errorf("unable to create temporary file %q: %w", tmpFilePath, err)
@@ -208,6 +576,11 @@ func (r *Reconciler) applyChangedFiles(ctx context.Context, log logr.Logger, fil
 				return fmt.Errorf("unable to rename temporary file %q to %q: %w", tmpFilePath, file.Path, err)
 			}
 
+			if err := r.applySELinuxContext(ctx, file); err != nil {
+				return err
+			}
+
+			metrics.FilesWritten.Inc()
 			log.Info("Successfully applied new or changed file", "path", file.Path)
 
 		case file.Content.ImageRef != nil:
@@ -215,6 +588,11 @@ func (r *Reconciler) applyChangedFiles(ctx context.Context, log logr.Logger, fil
 				return fmt.Errorf("unable to copy file %q from image %q to %q: %w", file.Content.ImageRef.FilePathInImage, file.Content.ImageRef.Image, file.Path, err)
 			}
 
+			if err := r.applySELinuxContext(ctx, file); err != nil {
+				return err
+			}
+
+			metrics.FilesWritten.Inc()
 			log.Info("Successfully applied new or changed file from image", "path", file.Path, "image", file.Content.ImageRef.Image)
 		}
 	}
@@ -222,8 +600,186 @@ func (r *Reconciler) applyChangedFiles(ctx context.Context, log logr.Logger, fil
 	return nil
 }
 
+// applySELinuxContext applies the SELinux context label of the given file, if one is configured. Since the function is
+// only called for new or changed files, unchanged files do not get relabeled on every reconciliation.
+func (r *Reconciler) applySELinuxContext(ctx context.Context, file extensionsv1alpha1.File) error {
+	if file.SELinuxContext == nil || r.FileLabeler == nil {
+		return nil
+	}
+
+	if err := r.FileLabeler.ApplyContext(ctx, file.Path, *file.SELinuxContext); err != nil {
+		return fmt.Errorf("unable to apply SELinux context %q to file %q: %w", *file.SELinuxContext, file.Path, err)
+	}
+
+	return nil
+}
+
+// sysctlFilePath is the path of the file in which the sysctl settings of the operating system config are persisted so
+// that they survive reboots of the node.
+const sysctlFilePath = "/etc/sysctl.d/99-gardener-node-agent.conf"
+
+// applySysctls persists the sysctl settings of the operating system config in /etc/sysctl.d and asks systemd-sysctl
+// to apply them. The file is only rewritten (and the reload only triggered) when the rendered content changed, so
+// reconciliations of an unchanged config do not touch the node.
+func (r *Reconciler) applySysctls(ctx context.Context, log logr.Logger, node client.Object, sysctls map[string]string) error {
+	existing, err := r.FS.ReadFile(sysctlFilePath)
+	if err != nil && !errors.Is(err, afero.ErrFileNotFound) && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to read sysctl file %q: %w", sysctlFilePath, err)
+	}
+
+	if len(sysctls) == 0 {
+		if len(existing) == 0 {
+			return nil
+		}
+
+		if err := r.FS.Remove(sysctlFilePath); err != nil {
+			return fmt.Errorf("unable to remove sysctl file %q: %w", sysctlFilePath, err)
+		}
+		log.Info("Successfully removed sysctl file", "path", sysctlFilePath)
+		return r.DBus.Restart(ctx, r.Recorder, node, "systemd-sysctl.service")
+	}
+
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(key + " = " + sysctls[key] + "\n")
+	}
+
+	if builder.String() == string(existing) {
+		return nil
+	}
+
+	if err := r.FS.MkdirAll(filepath.Dir(sysctlFilePath), fs.ModeDir); err != nil {
+		return fmt.Errorf("unable to create directory %q: %w", filepath.Dir(sysctlFilePath), err)
+	}
+
+	if err := r.FS.WriteFile(sysctlFilePath, []byte(builder.String()), 0644); err != nil {
+		return fmt.Errorf("unable to write sysctl file %q: %w", sysctlFilePath, err)
+	}
+
+	log.Info("Successfully applied new or changed sysctl file", "path", sysctlFilePath)
+	return r.DBus.Restart(ctx, r.Recorder, node, "systemd-sysctl.service")
+}
+
+// modulesLoadFilePath is the path of the file in which the kernel modules of the operating system config are persisted
+// so that they are loaded again after reboots of the node.
+const modulesLoadFilePath = "/etc/modules-load.d/99-gardener-node-agent.conf"
+
+// applyKernelModules persists the kernel modules of the operating system config in /etc/modules-load.d and asks
+// systemd-modules-load to load them. The file is only rewritten (and the load only triggered) when the rendered
+// content changed, so reconciliations of an unchanged config do not touch the node.
+func (r *Reconciler) applyKernelModules(ctx context.Context, log logr.Logger, node client.Object, kernelModules []string) error {
+	existing, err := r.FS.ReadFile(modulesLoadFilePath)
+	if err != nil && !errors.Is(err, afero.ErrFileNotFound) && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to read modules-load file %q: %w", modulesLoadFilePath, err)
+	}
+
+	if len(kernelModules) == 0 {
+		if len(existing) == 0 {
+			return nil
+		}
+
+		if err := r.FS.Remove(modulesLoadFilePath); err != nil {
+			return fmt.Errorf("unable to remove modules-load file %q: %w", modulesLoadFilePath, err)
+		}
+		log.Info("Successfully removed modules-load file", "path", modulesLoadFilePath)
+		return nil
+	}
+
+	content := strings.Join(kernelModules, "\n") + "\n"
+	if content == string(existing) {
+		return nil
+	}
+
+	if err := r.FS.MkdirAll(filepath.Dir(modulesLoadFilePath), fs.ModeDir); err != nil {
+		return fmt.Errorf("unable to create directory %q: %w", filepath.Dir(modulesLoadFilePath), err)
+	}
+
+	if err := r.FS.WriteFile(modulesLoadFilePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("unable to write modules-load file %q: %w", modulesLoadFilePath, err)
+	}
+
+	log.Info("Successfully applied new or changed modules-load file", "path", modulesLoadFilePath)
+	return r.DBus.Restart(ctx, r.Recorder, node, "systemd-modules-load.service")
+}
+
+// appendFileContent appends the given data to the file at the given path unless the data is already contained.
+func (r *Reconciler) appendFileContent(log logr.Logger, path string, data []byte, permissions fs.FileMode) error {
+	existing, err := r.FS.ReadFile(path)
+	if err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+		return fmt.Errorf("unable to read file %q: %w", path, err)
+	}
+
+	if bytes.Contains(existing, data) {
+		return nil
+	}
+
+	newContent := existing
+	if len(newContent) > 0 && !bytes.HasSuffix(newContent, []byte("\n")) {
+		newContent = append(newContent, '\n')
+	}
+	newContent = append(newContent, data...)
+
+	if err := r.FS.WriteFile(path, newContent, permissions); err != nil {
+		return fmt.Errorf("unable to append content to file %q: %w", path, err)
+	}
+
+	metrics.FilesWritten.Inc()
+	log.Info("Successfully appended content to file", "path", path)
+	return nil
+}
+
+// removeAppendedFileContent removes content which was previously appended to the file at the given path. The file
+// itself is kept since its base content is not managed by gardener-node-agent.
+func (r *Reconciler) removeAppendedFileContent(log logr.Logger, file extensionsv1alpha1.File) error {
+	data, err := extensionsv1alpha1helper.Decode(file.Content.Inline.Encoding, []byte(file.Content.Inline.Data))
+	if err != nil {
+		return fmt.Errorf("unable to decode data of file %q: %w", file.Path, err)
+	}
+
+	existing, err := r.FS.ReadFile(file.Path)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil
+		}
+		return fmt.Errorf("unable to read file %q: %w", file.Path, err)
+	}
+
+	newContent := bytes.Replace(existing, append([]byte("\n"), data...), nil, 1)
+	if bytes.Equal(newContent, existing) {
+		newContent = bytes.Replace(existing, data, nil, 1)
+	}
+	if bytes.Equal(newContent, existing) {
+		return nil
+	}
+
+	permissions := defaultFilePermissions
+	if file.Permissions != nil {
+		permissions = fs.FileMode(*file.Permissions)
+	}
+
+	if err := r.FS.WriteFile(file.Path, newContent, permissions); err != nil {
+		return fmt.Errorf("unable to remove appended content from file %q: %w", file.Path, err)
+	}
+
+	log.Info("Successfully removed previously appended content from file", "path", file.Path)
+	return nil
+}
+
 func (r *Reconciler) removeDeletedFiles(log logr.Logger, files []extensionsv1alpha1.File) error {
 	for _, file := range files {
+		if file.Content.Inline != nil && file.Content.Inline.Append {
+			if err := r.removeAppendedFileContent(log, file); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if err := r.FS.Remove(file.Path); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
 			return fmt.Errorf("unable to delete no longer needed file %q: %w", file.Path, err)
 		}
@@ -246,6 +802,12 @@ func (r *Reconciler) applyChangedUnits(ctx context.Context, log logr.Logger, uni
 
 			newUnitContent := []byte(*unit.Content)
 			if !bytes.Equal(newUnitContent, oldUnitContent) {
+				if r.UnitVerifier != nil {
+					if err := r.UnitVerifier.Verify(ctx, unit.Name, *unit.Content); err != nil {
+						return fmt.Errorf("refusing to apply invalid unit %q: %w", unit.Name, err)
+					}
+				}
+
 				if err := r.FS.WriteFile(unitFilePath, newUnitContent, defaultFilePermissions); err != nil {
 					return fmt.Errorf("unable to write unit file %q for %q: %w", unitFilePath, unit.Name, err)
 				}
@@ -346,6 +908,8 @@ func (r *Reconciler) executeUnitCommands(ctx context.Context, log logr.Logger, n
 		fns                          []flow.TaskFn
 	)
 
+	var deferredUnitNames []string
+
 	for _, u := range units {
 		unit := u
 
@@ -354,16 +918,29 @@ func (r *Reconciler) executeUnitCommands(ctx context.Context, log logr.Logger, n
 			continue
 		}
 
+		if r.Config.DeferUnitRestarts && !nodeAllowsRestarts(node) &&
+			pointer.BoolDeref(unit.Enable, true) && (unit.Command == nil || *unit.Command != extensionsv1alpha1.CommandStop) {
+			deferredUnitNames = append(deferredUnitNames, unit.Name)
+			continue
+		}
+
 		fns = append(fns, func(ctx context.Context) error {
 			if !pointer.BoolDeref(unit.Enable, true) || (unit.Command != nil && *unit.Command == extensionsv1alpha1.CommandStop) {
 				if err := r.DBus.Stop(ctx, r.Recorder, node, unit.Name); err != nil {
 					return fmt.Errorf("unable to stop unit %q: %w", unit.Name, err)
 				}
 				log.Info("Successfully stopped unit", "unitName", unit.Name)
+			} else if pointer.BoolDeref(unit.ReloadInsteadOfRestart, false) {
+				if err := r.DBus.Reload(ctx, r.Recorder, node, unit.Name); err != nil {
+					return fmt.Errorf("unable to reload unit %q: %w", unit.Name, err)
+				}
+				metrics.UnitsRestarted.Inc()
+				log.Info("Successfully reloaded unit", "unitName", unit.Name)
 			} else {
 				if err := r.DBus.Restart(ctx, r.Recorder, node, unit.Name); err != nil {
 					return fmt.Errorf("unable to restart unit %q: %w", unit.Name, err)
 				}
+				metrics.UnitsRestarted.Inc()
 				log.Info("Successfully restarted unit", "unitName", unit.Name)
 			}
 
@@ -371,5 +948,99 @@ func (r *Reconciler) executeUnitCommands(ctx context.Context, log logr.Logger, n
 		})
 	}
 
-	return mustRestartGardenerNodeAgent, flow.Parallel(fns...)(ctx)
+	if len(deferredUnitNames) > 0 {
+		if err := r.addPendingUnitRestarts(deferredUnitNames); err != nil {
+			return false, fmt.Errorf("unable to persist pending unit restarts: %w", err)
+		}
+		log.Info("Deferred disruptive restart of units until restarts are allowed for this node", "unitNames", deferredUnitNames)
+	}
+
+	// The commands are executed sequentially in operating system config order so that the sequence of systemd actions
+	// is deterministic (see the ordering guarantee documented on applyOperatingSystemConfig).
+	return mustRestartGardenerNodeAgent, flow.Sequential(fns...)(ctx)
+}
+
+// nodeAllowsRestarts returns true if the node carries the AnnotationRestartAllowed annotation.
+func nodeAllowsRestarts(node client.Object) bool {
+	if node == nil {
+		return false
+	}
+	if partialObjectMetadata, ok := node.(*metav1.PartialObjectMetadata); ok && partialObjectMetadata == nil {
+		return false
+	}
+
+	_, ok := node.GetAnnotations()[AnnotationRestartAllowed]
+	return ok
+}
+
+type pendingUnitRestarts struct {
+	UnitNames []string `json:"unitNames"`
+}
+
+func (r *Reconciler) readPendingUnitRestarts() (*pendingUnitRestarts, error) {
+	filePath := r.pendingUnitRestartsFilePath()
+
+	data, err := r.FS.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return &pendingUnitRestarts{}, nil
+		}
+		return nil, fmt.Errorf("unable to read pending unit restarts file %q: %w", filePath, err)
+	}
+
+	pending := &pendingUnitRestarts{}
+	if err := json.Unmarshal(data, pending); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal pending unit restarts file %q: %w", filePath, err)
+	}
+
+	return pending, nil
+}
+
+func (r *Reconciler) addPendingUnitRestarts(unitNames []string) error {
+	pending, err := r.readPendingUnitRestarts()
+	if err != nil {
+		return err
+	}
+
+	pending.UnitNames = sets.List(sets.New(pending.UnitNames...).Insert(unitNames...))
+
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("unable to marshal pending unit restarts: %w", err)
+	}
+
+	return r.FS.WriteFile(r.pendingUnitRestartsFilePath(), data, 0644)
+}
+
+// executePendingUnitRestarts restarts units whose restart was deferred during an earlier reconciliation as soon as
+// restarts are allowed for the node.
+func (r *Reconciler) executePendingUnitRestarts(ctx context.Context, log logr.Logger, node *metav1.PartialObjectMetadata) error {
+	pending, err := r.readPendingUnitRestarts()
+	if err != nil {
+		return err
+	}
+
+	if len(pending.UnitNames) == 0 {
+		return nil
+	}
+
+	if node == nil || !nodeAllowsRestarts(node) {
+		log.Info("Restarts are not yet allowed for this node, keeping unit restarts pending", "unitNames", pending.UnitNames)
+		return nil
+	}
+
+	for _, unitName := range pending.UnitNames {
+		if err := r.DBus.Restart(ctx, r.Recorder, node, unitName); err != nil {
+			return fmt.Errorf("unable to restart pending unit %q: %w", unitName, err)
+		}
+		metrics.UnitsRestarted.Inc()
+		log.Info("Successfully restarted pending unit", "unitName", unitName)
+	}
+
+	filePath := r.pendingUnitRestartsFilePath()
+	if err := r.FS.Remove(filePath); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+		return fmt.Errorf("unable to remove pending unit restarts file %q: %w", filePath, err)
+	}
+
+	return nil
 }
@@ -0,0 +1,1155 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	testclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/logger"
+	nodeagentconfig "github.com/gardener/gardener/pkg/nodeagent/apis/config"
+	nodeagentv1alpha1 "github.com/gardener/gardener/pkg/nodeagent/apis/config/v1alpha1"
+	. "github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
+	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+)
+
+var _ = Describe("Reconciler", func() {
+	Describe("deferred unit restarts", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient client.Client
+			fakeDBus   *fakedbus.DBus
+			fakeFS     afero.Afero
+			reconciler *Reconciler
+
+			node    *corev1.Node
+			secret  *corev1.Secret
+			request reconcile.Request
+
+			pendingUnitRestartsFilePath = nodeagentv1alpha1.BaseDir + "/pending-unit-restarts.json"
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#foo"),
+					}},
+				},
+			}
+			oscRaw := encodeOperatingSystemConfig(osc)
+
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": oscRaw},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build()
+			fakeDBus = fakedbus.New()
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler = &Reconciler{
+				Client: fakeClient,
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+					DeferUnitRestarts: true,
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakeDBus,
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should defer unit restarts until restarts are allowed for the node", func() {
+			By("Reconcile while restarts are not allowed")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, action := range fakeDBus.Actions {
+				Expect(action.Action).NotTo(Equal(fakedbus.ActionRestart), "unit restart must be deferred")
+			}
+
+			pendingFileExists, err := fakeFS.Exists(pendingUnitRestartsFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingFileExists).To(BeTrue())
+
+			pendingFileContent, err := fakeFS.ReadFile(pendingUnitRestartsFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(pendingFileContent)).To(ContainSubstring("foo.service"))
+
+			By("Reconcile again without allowing restarts")
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, action := range fakeDBus.Actions {
+				Expect(action.Action).NotTo(Equal(fakedbus.ActionRestart), "unit restart must still be deferred")
+			}
+
+			By("Allow restarts for the node")
+			patch := client.MergeFrom(node.DeepCopy())
+			metav1.SetMetaDataAnnotation(&node.ObjectMeta, AnnotationRestartAllowed, "true")
+			Expect(fakeClient.Patch(ctx, node, patch)).To(Succeed())
+
+			By("Reconcile after restarts were allowed")
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDBus.Actions).To(ContainElement(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"foo.service"}}))
+
+			pendingFileExists, err = fakeFS.Exists(pendingUnitRestartsFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingFileExists).To(BeFalse())
+		})
+	})
+
+	Describe("merging multiple OSC secrets", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient client.Client
+			fakeFS     afero.Afero
+			reconciler *Reconciler
+
+			request reconcile.Request
+		)
+
+		BeforeEach(func() {
+			baseOSC := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{
+						{Path: "/base-and-overlay", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "base"}}},
+						{Path: "/base-only", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "base only"}}},
+					},
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#base"),
+					}},
+				},
+			}
+			overlayOSC := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{
+						{Path: "/base-and-overlay", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "overlay"}}},
+						{Path: "/overlay-only", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "overlay only"}}},
+					},
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#overlay"),
+					}},
+				},
+			}
+
+			baseSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-base", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(baseOSC)},
+			}
+			overlaySecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-overlay", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(overlayOSC)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(baseSecret)}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, baseSecret, overlaySecret).Build()
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler = &Reconciler{
+				Client: fakeClient,
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:            &metav1.Duration{Duration: time.Minute},
+					SecretName:            baseSecret.Name,
+					AdditionalSecretNames: []string{overlaySecret.Name},
+					KubernetesVersion:     semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should apply the merged OSC with the overlay taking precedence", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			fileContent, err := fakeFS.ReadFile("/base-and-overlay")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(fileContent)).To(Equal("overlay"))
+
+			fileContent, err = fakeFS.ReadFile("/base-only")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(fileContent)).To(Equal("base only"))
+
+			fileContent, err = fakeFS.ReadFile("/overlay-only")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(fileContent)).To(Equal("overlay only"))
+
+			unitContent, err := fakeFS.ReadFile("/etc/systemd/system/foo.service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(unitContent)).To(Equal("#overlay"))
+		})
+	})
+
+	Describe("pause file", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient client.Client
+			fakeFS     afero.Afero
+			reconciler *Reconciler
+
+			node    *corev1.Node
+			request reconcile.Request
+
+			pausedFilePath = nodeagentv1alpha1.BaseDir + "/paused"
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#foo"),
+					}},
+				},
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).WithStatusSubresource(node).Build()
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler = &Reconciler{
+				Client: fakeClient,
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		pausedCondition := func() *corev1.NodeCondition {
+			ExpectWithOffset(1, fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == PausedConditionType {
+					return &condition
+				}
+			}
+			return nil
+		}
+
+		It("should halt applies while the pause file exists and resume when it is removed", func() {
+			By("Create the pause file")
+			Expect(fakeFS.WriteFile(pausedFilePath, nil, 0644)).To(Succeed())
+
+			By("Reconcile while paused")
+			result, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+			unitFileExists, err := fakeFS.Exists("/etc/systemd/system/foo.service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unitFileExists).To(BeFalse(), "unit file must not be applied while paused")
+
+			condition := pausedCondition()
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(condition.Reason).To(Equal("PauseFileExists"))
+
+			By("Remove the pause file")
+			Expect(fakeFS.Remove(pausedFilePath)).To(Succeed())
+
+			By("Reconcile after the pause file was removed")
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			unitFileExists, err = fakeFS.Exists("/etc/systemd/system/foo.service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unitFileExists).To(BeTrue(), "unit file must be applied after resuming")
+
+			condition = pausedCondition()
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("ReconciliationActive"))
+		})
+
+		It("should not add the condition to unpaused nodes", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pausedCondition()).To(BeNil())
+		})
+	})
+
+	Describe("custom state directory", func() {
+		It("should persist the 'last-applied' OSC file under the configured state directory", func() {
+			ctx := context.Background()
+
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#foo"),
+					}},
+				},
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			fakeFS := afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler := &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    "/data/gardener-node-agent",
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+			Expect(err).NotTo(HaveOccurred())
+
+			lastAppliedFileExists, err := fakeFS.Exists("/data/gardener-node-agent/last-applied-osc.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastAppliedFileExists).To(BeTrue())
+		})
+	})
+
+	Describe("node events", func() {
+		It("should record a SelfRestartRequested event when the gardener-node-agent unit changes", func() {
+			ctx := context.Background()
+
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    nodeagentv1alpha1.UnitName,
+						Content: pointer.String("#gna"),
+					}},
+				},
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			recorder := record.NewFakeRecorder(32)
+
+			reconciler := &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    recorder,
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          afero.Afero{Fs: afero.NewMemMapFs()},
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("SelfRestartRequested")))
+		})
+	})
+
+	Describe("sysctl settings", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient client.Client
+			fakeDBus   *fakedbus.DBus
+			fakeFS     afero.Afero
+			reconciler *Reconciler
+
+			node    *corev1.Node
+			request reconcile.Request
+
+			sysctlFilePath = "/etc/sysctl.d/99-gardener-node-agent.conf"
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Sysctls: map[string]string{
+						"net.ipv4.ip_forward":           "1",
+						"fs.inotify.max_user_instances": "8192",
+					},
+				},
+			}
+
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build()
+			fakeDBus = fakedbus.New()
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler = &Reconciler{
+				Client: fakeClient,
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakeDBus,
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should write the sysctl file and reload systemd-sysctl", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := fakeFS.ReadFile(sysctlFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("fs.inotify.max_user_instances = 8192\nnet.ipv4.ip_forward = 1\n"))
+
+			Expect(fakeDBus.Actions).To(ContainElement(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"systemd-sysctl.service"}}))
+		})
+
+		It("should not reload again when the sysctl settings are unchanged", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Force a second full reconciliation by dropping the checksum annotation")
+			patch := client.MergeFrom(node.DeepCopy())
+			node.Annotations = nil
+			Expect(fakeClient.Patch(ctx, node, patch)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			restarts := 0
+			for _, action := range fakeDBus.Actions {
+				if action.Action == fakedbus.ActionRestart && action.UnitNames[0] == "systemd-sysctl.service" {
+					restarts++
+				}
+			}
+			Expect(restarts).To(Equal(1))
+		})
+	})
+
+	Describe("rollback on failure", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient client.Client
+			fakeFS     afero.Afero
+			verifier   *fakeUnitVerifier
+			reconciler *Reconciler
+
+			node    *corev1.Node
+			secret  *corev1.Secret
+			request reconcile.Request
+		)
+
+		oscWith := func(fileData, unitContent string) *extensionsv1alpha1.OperatingSystemConfig {
+			return &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{{
+						Path:    "/etc/foo",
+						Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: fileData}},
+					}},
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String(unitContent),
+					}},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(oscWith("v1", "#v1"))},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).WithStatusSubresource(node).Build()
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+			verifier = &fakeUnitVerifier{invalidUnits: map[string]bool{}}
+
+			reconciler = &Reconciler{
+				Client: fakeClient,
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+					RollbackOnFailure: true,
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				UnitVerifier:                verifier,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should restore the last applied OSC when applying a new one fails", func() {
+			By("Apply the initial OSC successfully")
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Push a new OSC whose unit fails the verification")
+			secret.Data["osc.yaml"] = encodeOperatingSystemConfig(oscWith("v2", "#v2"))
+			Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+			verifier.invalidUnits["foo.service"] = true
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring(`refusing to apply invalid unit "foo.service"`)))
+
+			By("Verify the previous files and units were restored")
+			fileContent, err := fakeFS.ReadFile("/etc/foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(fileContent)).To(Equal("v1"))
+
+			unitContent, err := fakeFS.ReadFile("/etc/systemd/system/foo.service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(unitContent)).To(Equal("#v1"))
+
+			By("Verify the rollback was recorded in the node condition")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(node.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", RolledBackConditionType),
+				HaveField("Status", corev1.ConditionTrue),
+				HaveField("Message", ContainSubstring("foo.service")),
+			)))
+		})
+	})
+
+	Describe("unit verification", func() {
+		var (
+			ctx = context.Background()
+
+			fakeFS     afero.Afero
+			verifier   *fakeUnitVerifier
+			reconciler *Reconciler
+
+			request reconcile.Request
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{
+						{Name: "good.service", Content: pointer.String("#good")},
+						{Name: "bad.service", Content: pointer.String("#bad")},
+					},
+				},
+			}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+			verifier = &fakeUnitVerifier{invalidUnits: map[string]bool{}}
+
+			reconciler = &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				UnitVerifier:                verifier,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should refuse to write a unit failing the verification", func() {
+			verifier.invalidUnits["bad.service"] = true
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring(`refusing to apply invalid unit "bad.service"`)))
+
+			badUnitExists, err := fakeFS.Exists("/etc/systemd/system/bad.service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(badUnitExists).To(BeFalse())
+		})
+
+		It("should write all units when the verification passes", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(verifier.verifiedUnits).To(ConsistOf("good.service", "bad.service"))
+
+			for _, unitName := range []string{"good.service", "bad.service"} {
+				unitExists, err := fakeFS.Exists("/etc/systemd/system/" + unitName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(unitExists).To(BeTrue(), unitName+" must be written")
+			}
+		})
+	})
+
+	Describe("SELinux context labels", func() {
+		var (
+			ctx = context.Background()
+
+			fakeFS     afero.Afero
+			labeler    *fakeFileLabeler
+			reconciler *Reconciler
+
+			request reconcile.Request
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{
+						{
+							Path:           "/etc/labeled",
+							Content:        extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "labeled"}},
+							SELinuxContext: pointer.String("system_u:object_r:etc_t:s0"),
+						},
+						{
+							Path:    "/etc/unlabeled",
+							Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "unlabeled"}},
+						},
+					},
+				},
+			}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+			labeler = &fakeFileLabeler{appliedContexts: map[string]string{}}
+
+			reconciler = &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				FileLabeler:                 labeler,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should apply the configured context to labeled files only", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := fakeFS.ReadFile("/etc/labeled")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("labeled"))
+
+			Expect(labeler.appliedContexts).To(Equal(map[string]string{"/etc/labeled": "system_u:object_r:etc_t:s0"}))
+		})
+
+		It("should fail when the context cannot be applied", func() {
+			labeler.failForPath = "/etc/labeled"
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(ContainSubstring(`unable to apply SELinux context "system_u:object_r:etc_t:s0" to file "/etc/labeled"`)))
+		})
+	})
+
+	Describe("kernel modules", func() {
+		var (
+			ctx = context.Background()
+
+			fakeDBus   *fakedbus.DBus
+			fakeFS     afero.Afero
+			reconciler *Reconciler
+
+			request reconcile.Request
+
+			modulesLoadFilePath = "/etc/modules-load.d/99-gardener-node-agent.conf"
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					KernelModules: []string{"br_netfilter", "overlay"},
+				},
+			}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeDBus = fakedbus.New()
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler = &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakeDBus,
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should write the modules-load file and load the modules", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := fakeFS.ReadFile(modulesLoadFilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("br_netfilter\noverlay\n"))
+
+			Expect(fakeDBus.Actions).To(ContainElement(fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{"systemd-modules-load.service"}}))
+		})
+	})
+
+	Describe("node inventory annotations", func() {
+		var (
+			ctx = context.Background()
+
+			fakeClient client.Client
+			reconciler *Reconciler
+
+			node    *corev1.Node
+			request reconcile.Request
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#foo"),
+					}},
+				},
+			}
+
+			node = &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Status: corev1.NodeStatus{
+					NodeInfo: corev1.NodeSystemInfo{
+						ContainerRuntimeVersion: "containerd://1.7.2",
+						KernelVersion:           "5.15.0",
+					},
+				},
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeClient = fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build()
+
+			reconciler = &Reconciler{
+				Client: fakeClient,
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          afero.Afero{Fs: afero.NewMemMapFs()},
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should annotate the node with the detected versions when enabled", func() {
+			reconciler.Config.NodeInventoryAnnotations = true
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(node.Annotations).To(And(
+				HaveKeyWithValue(AnnotationContainerRuntimeVersion, "containerd://1.7.2"),
+				HaveKeyWithValue(AnnotationKernelVersion, "5.15.0"),
+			))
+		})
+
+		It("should not annotate the node when disabled", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+			Expect(node.Annotations).NotTo(Or(
+				HaveKey(AnnotationContainerRuntimeVersion),
+				HaveKey(AnnotationKernelVersion),
+			))
+		})
+	})
+
+	Describe("conflicting file declarations", func() {
+		var (
+			ctx = context.Background()
+
+			fakeFS     afero.Afero
+			reconciler *Reconciler
+			request    reconcile.Request
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{
+						{
+							Name:    "foo.service",
+							Content: pointer.String("#foo"),
+							Files:   []extensionsv1alpha1.File{{Path: "/etc/foo", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}}}},
+						},
+						{
+							Name:    "bar.service",
+							Content: pointer.String("#bar"),
+							Files:   []extensionsv1alpha1.File{{Path: "/etc/foo", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "bar"}}}},
+						},
+					},
+				},
+			}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			reconciler = &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          fakeFS,
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should fail with a descriptive error and not write anything when two units declare the same file path", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).To(MatchError(And(
+				ContainSubstring(`file "/etc/foo" is declared by multiple owners`),
+				ContainSubstring(`unit "foo.service"`),
+				ContainSubstring(`unit "bar.service"`),
+			)))
+
+			fileExists, err := fakeFS.Exists("/etc/foo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fileExists).To(BeFalse())
+
+			unitFileExists, err := fakeFS.Exists("/etc/systemd/system/foo.service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unitFileExists).To(BeFalse())
+		})
+	})
+
+	Describe("deterministic systemd action ordering", func() {
+		var (
+			ctx = context.Background()
+
+			fakeDBus   *fakedbus.DBus
+			reconciler *Reconciler
+			request    reconcile.Request
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Units: []extensionsv1alpha1.Unit{
+						{Name: "aaa.service", Content: pointer.String("#aaa")},
+						{Name: "bbb.service", Content: pointer.String("#bbb")},
+						{Name: "ccc.service", Content: pointer.String("#ccc"), Enable: pointer.Bool(false)},
+					},
+				},
+			}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			fakeDBus = fakedbus.New()
+
+			reconciler = &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:        &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion: semver.MustParse("1.27.4"),
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakeDBus,
+				FS:                          afero.Afero{Fs: afero.NewMemMapFs()},
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should enable units, reload the daemon, and only then restart or stop units, in OSC order", func() {
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDBus.Actions).To(Equal([]fakedbus.SystemdAction{
+				{Action: fakedbus.ActionEnable, UnitNames: []string{"aaa.service"}},
+				{Action: fakedbus.ActionEnable, UnitNames: []string{"bbb.service"}},
+				{Action: fakedbus.ActionDisable, UnitNames: []string{"ccc.service"}},
+				{Action: fakedbus.ActionDaemonReload},
+				{Action: fakedbus.ActionRestart, UnitNames: []string{"aaa.service"}},
+				{Action: fakedbus.ActionRestart, UnitNames: []string{"bbb.service"}},
+				{Action: fakedbus.ActionStop, UnitNames: []string{"ccc.service"}},
+			}))
+		})
+	})
+
+	Describe("action log summarization", func() {
+		var (
+			logBuffer  *gbytes.Buffer
+			reconciler *Reconciler
+			request    reconcile.Request
+		)
+
+		BeforeEach(func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{
+						{Path: "/etc/foo", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}}},
+						{Path: "/etc/bar", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "bar"}}},
+					},
+					Units: []extensionsv1alpha1.Unit{{
+						Name:    "foo.service",
+						Content: pointer.String("#foo"),
+					}},
+				},
+			}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osc-secret", Namespace: "kube-system"},
+				Data:       map[string][]byte{"osc.yaml": encodeOperatingSystemConfig(osc)},
+			}
+			request = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+
+			logBuffer = gbytes.NewBuffer()
+
+			reconciler = &Reconciler{
+				Client: fakeclient.NewClientBuilder().WithScheme(kubernetes.SeedScheme).WithObjects(node, secret).Build(),
+				Config: nodeagentconfig.OperatingSystemConfigControllerConfig{
+					SyncPeriod:          &metav1.Duration{Duration: time.Minute},
+					KubernetesVersion:   semver.MustParse("1.27.4"),
+					SummarizeActionLogs: true,
+				},
+				Recorder:                    record.NewFakeRecorder(32),
+				Clock:                       testclock.NewFakeClock(time.Now()),
+				DBus:                        fakedbus.New(),
+				FS:                          afero.Afero{Fs: afero.NewMemMapFs()},
+				NodeName:                    node.Name,
+				StateDir:                    nodeagentv1alpha1.BaseDir,
+				CancelContext:               func() {},
+				LastSuccessfulReconcileTime: &LastSuccessfulReconcileTime{},
+			}
+		})
+
+		It("should log a summary instead of per-item logs at default verbosity", func() {
+			log := logger.MustNewZapLogger(logger.InfoLevel, logger.FormatJSON, logzap.WriteTo(logBuffer))
+
+			_, err := reconciler.Reconcile(logf.IntoContext(context.Background(), log), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			logs := string(logBuffer.Contents())
+			Expect(logs).To(ContainSubstring("wrote 2 file(s), removed 0 file(s), applied 1 unit(s), removed 0 unit(s)"))
+			Expect(logs).NotTo(ContainSubstring("Successfully applied new or changed file"))
+			Expect(logs).NotTo(ContainSubstring("Successfully applied new or changed unit"))
+		})
+
+		It("should log the per-item details at a higher verbosity", func() {
+			log := logger.MustNewZapLogger(logger.DebugLevel, logger.FormatJSON, logzap.WriteTo(logBuffer))
+
+			_, err := reconciler.Reconcile(logf.IntoContext(context.Background(), log), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			logs := string(logBuffer.Contents())
+			Expect(logs).To(ContainSubstring("Successfully applied new or changed file"))
+			Expect(logs).To(ContainSubstring("wrote 2 file(s), removed 0 file(s), applied 1 unit(s), removed 0 unit(s)"))
+		})
+
+		It("should keep the per-item logs at default verbosity when summarization is disabled", func() {
+			reconciler.Config.SummarizeActionLogs = false
+			log := logger.MustNewZapLogger(logger.InfoLevel, logger.FormatJSON, logzap.WriteTo(logBuffer))
+
+			_, err := reconciler.Reconcile(logf.IntoContext(context.Background(), log), request)
+			Expect(err).NotTo(HaveOccurred())
+
+			logs := string(logBuffer.Contents())
+			Expect(logs).To(ContainSubstring("Successfully applied new or changed file"))
+			Expect(logs).NotTo(ContainSubstring("Summary of performed actions"))
+		})
+	})
+})
+
+func encodeOperatingSystemConfig(osc *extensionsv1alpha1.OperatingSystemConfig) []byte {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(extensionsv1alpha1.AddToScheme(scheme))
+	yamlSerializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+	versions := schema.GroupVersions([]schema.GroupVersion{extensionsv1alpha1.SchemeGroupVersion})
+	codec := serializer.NewCodecFactory(scheme).CodecForVersions(yamlSerializer, yamlSerializer, versions, versions)
+
+	oscRaw, err := runtime.Encode(codec, osc)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return oscRaw
+}
+
+type fakeUnitVerifier struct {
+	invalidUnits  map[string]bool
+	verifiedUnits []string
+}
+
+func (f *fakeUnitVerifier) Verify(_ context.Context, unitName, _ string) error {
+	f.verifiedUnits = append(f.verifiedUnits, unitName)
+	if f.invalidUnits[unitName] {
+		return fmt.Errorf("unit %s is invalid", unitName)
+	}
+	return nil
+}
+
+type fakeFileLabeler struct {
+	appliedContexts map[string]string
+	failForPath     string
+}
+
+func (f *fakeFileLabeler) ApplyContext(_ context.Context, path, seLinuxContext string) error {
+	if path == f.failForPath {
+		return fmt.Errorf("cannot label file %s", path)
+	}
+	f.appliedContexts[path] = seLinuxContext
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+)
+
+// UnitVerifier validates systemd unit content before it is written to disk, protecting the node against malformed
+// units which could wedge systemd.
+type UnitVerifier interface {
+	// Verify returns an error when the given unit content is not a valid systemd unit.
+	Verify(ctx context.Context, unitName, content string) error
+}
+
+// SystemdAnalyzeVerifier validates unit content by running `systemd-analyze verify` on a temporary copy of the unit
+// file.
+type SystemdAnalyzeVerifier struct{}
+
+// Verify writes the given unit content to a temporary file and runs `systemd-analyze verify` on it.
+func (SystemdAnalyzeVerifier) Verify(ctx context.Context, unitName, content string) error {
+	tmpDir, err := os.MkdirTemp("", "gardener-node-agent-verify-*")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary directory: %w", err)
+	}
+	defer func() { utilruntime.HandleError(os.RemoveAll(tmpDir)) }()
+
+	unitFilePath := filepath.Join(tmpDir, unitName)
+	if err := os.WriteFile(unitFilePath, []byte(content), 0600); err != nil {
+		return fmt.Errorf("unable to write temporary unit file %q: %w", unitFilePath, err)
+	}
+
+	if output, err := exec.CommandContext(ctx, "systemd-analyze", "verify", unitFilePath).CombinedOutput(); err != nil {
+		return fmt.Errorf("systemd-analyze verify failed for unit %q: %s: %w", unitName, string(output), err)
+	}
+
+	return nil
+}
@@ -40,6 +40,9 @@ type DBus interface {
 	Stop(ctx context.Context, recorder record.EventRecorder, node runtime.Object, unitName string) error
 	// Restart the given unit and record an event to the node object, same as executing "systemctl restart unit".
 	Restart(ctx context.Context, recorder record.EventRecorder, node runtime.Object, unitName string) error
+	// Reload the given unit and record an event to the node object, same as executing "systemctl reload-or-restart unit".
+	// If the unit does not support reloading, it is restarted instead.
+	Reload(ctx context.Context, recorder record.EventRecorder, node runtime.Object, unitName string) error
 }
 
 type db struct{}
@@ -136,6 +139,28 @@ func (_ *db) Restart(ctx context.Context, recorder record.EventRecorder, node ru
 	return nil
 }
 
+func (_ *db) Reload(ctx context.Context, recorder record.EventRecorder, node runtime.Object, unitName string) error {
+	dbc, err := dbus.NewWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to connect to dbus: %w", err)
+	}
+	defer dbc.Close()
+
+	jobCh := make(chan string)
+
+	if _, err := dbc.ReloadOrRestartUnitContext(ctx, unitName, "replace", jobCh); err != nil {
+		return fmt.Errorf("unable to reload unit %s: %w", unitName, err)
+	}
+
+	completion := <-jobCh
+	if completion != done {
+		err = fmt.Errorf("reload failed for %s, due %s", unitName, completion)
+	}
+
+	recordEvent(recorder, node, err, unitName, "SystemDUnitReload", "reload")
+	return err
+}
+
 func (_ *db) DaemonReload(ctx context.Context) error {
 	dbc, err := dbus.NewWithContext(ctx)
 	if err != nil {
@@ -34,6 +34,8 @@ const (
 	ActionDisable
 	// ActionEnable is constant for the 'Enable' action.
 	ActionEnable
+	// ActionReload is constant for the 'Reload' action.
+	ActionReload
 	// ActionRestart is constant for the 'Restart' action.
 	ActionRestart
 	// ActionStart is constant for the 'Start' action.
@@ -98,6 +100,18 @@ func (d *DBus) Enable(_ context.Context, unitNames ...string) error {
 	return nil
 }
 
+// Reload implements dbus.DBus.
+func (d *DBus) Reload(_ context.Context, _ record.EventRecorder, _ runtime.Object, unitName string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.Actions = append(d.Actions, SystemdAction{
+		Action:    ActionReload,
+		UnitNames: []string{unitName},
+	})
+	return nil
+}
+
 // Restart implements dbus.DBus.
 func (d *DBus) Restart(_ context.Context, _ record.EventRecorder, _ runtime.Object, unitName string) error {
 	d.mutex.Lock()
@@ -0,0 +1,66 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	runtimemetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Namespace is the metric namespace for the gardener-node-agent.
+const Namespace = "gardener_node_agent"
+
+var (
+	// Factory is used for registering metrics in the controller-runtime metrics registry.
+	Factory = promauto.With(runtimemetrics.Registry)
+
+	// FilesWritten defines the counter operatingsystemconfig_files_written_total.
+	FilesWritten = Factory.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "operatingsystemconfig_files_written_total",
+			Help:      "Total number of files written to disk when applying operating system configs.",
+		},
+	)
+
+	// UnitsRestarted defines the counter operatingsystemconfig_units_restarted_total.
+	UnitsRestarted = Factory.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "operatingsystemconfig_units_restarted_total",
+			Help:      "Total number of systemd units restarted (or reloaded) when applying operating system configs.",
+		},
+	)
+
+	// ReconcileErrors defines the counter operatingsystemconfig_reconcile_errors_total.
+	ReconcileErrors = Factory.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "operatingsystemconfig_reconcile_errors_total",
+			Help:      "Total number of failed reconciliations of operating system configs.",
+		},
+	)
+
+	// ReconcileDuration defines the histogram operatingsystemconfig_reconcile_duration_seconds.
+	ReconcileDuration = Factory.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "operatingsystemconfig_reconcile_duration_seconds",
+			Help:      "Duration of reconciliations of operating system configs.",
+			Buckets:   prometheus.DefBuckets,
+		},
+	)
+)
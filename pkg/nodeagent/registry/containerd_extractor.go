@@ -85,13 +85,15 @@ func (e *containerdExtractor) CopyFromImage(ctx context.Context, imageRef string
 	if err := mountImage(ctx, image, snapshotter, imageMountDirectory); err != nil {
 		return err
 	}
+	// unmount the image even if copying the file fails, otherwise the mount would leak until the next reboot
+	defer func() { utilruntime.HandleError(unmountImage(ctx, snapshotter, imageMountDirectory)) }()
 
 	source := path.Join(imageMountDirectory, filePathInImage)
 	if err := CopyFile(fs, source, destination, permissions); err != nil {
 		return fmt.Errorf("error copying file %s to %s: %w", source, destination, err)
 	}
 
-	return unmountImage(ctx, snapshotter, imageMountDirectory)
+	return nil
 }
 
 func mountImage(ctx context.Context, image containerd.Image, snapshotter snapshots.Snapshotter, directory string) error {
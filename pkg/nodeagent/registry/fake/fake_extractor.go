@@ -21,10 +21,15 @@ import (
 	"path"
 
 	"github.com/spf13/afero"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
 	"github.com/gardener/gardener/pkg/nodeagent/registry"
 )
 
+// MountDirectoryPrefix is the prefix of the temporary directories simulating the image mounts of the containerd
+// extractor. Tests can use it to verify that no mount directory is leaked.
+const MountDirectoryPrefix = "fake-image-mount-"
+
 type fakeRegistryExtractor struct {
 	fakeFS          afero.Afero
 	sourceDirectory string
@@ -37,11 +42,24 @@ func NewExtractor(fakeFS afero.Afero, sourceDirectory string) registry.Extractor
 	return &fakeRegistryExtractor{fakeFS: fakeFS, sourceDirectory: sourceDirectory}
 }
 
-// CopyFromImage copies a file from a given image reference to the destination file.
+// CopyFromImage copies a file from a given image reference to the destination file. It mimics the mount handling of the
+// containerd extractor by staging the file in a temporary mount directory which is cleaned up even if copying the file
+// to the destination fails.
 func (e *fakeRegistryExtractor) CopyFromImage(_ context.Context, _ string, filePathInImage string, destination string, permissions fs.FileMode) error {
+	imageMountDirectory, err := e.fakeFS.TempDir("", MountDirectoryPrefix)
+	if err != nil {
+		return fmt.Errorf("error creating temp directory: %w", err)
+	}
+	defer func() { utilruntime.HandleError(e.fakeFS.RemoveAll(imageMountDirectory)) }()
+
 	source := path.Join(e.sourceDirectory, filePathInImage)
-	if err := registry.CopyFile(e.fakeFS, source, destination, permissions); err != nil {
-		return fmt.Errorf("error copying file %s to %s: %w", source, destination, err)
+	mountedSource := path.Join(imageMountDirectory, path.Base(filePathInImage))
+	if err := registry.CopyFile(e.fakeFS, source, mountedSource, 0755); err != nil {
+		return fmt.Errorf("error copying file %s to %s: %w", source, mountedSource, err)
+	}
+
+	if err := registry.CopyFile(e.fakeFS, mountedSource, destination, permissions); err != nil {
+		return fmt.Errorf("error copying file %s to %s: %w", mountedSource, destination, err)
 	}
 
 	return nil
@@ -0,0 +1,76 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry_test
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"github.com/gardener/gardener/pkg/nodeagent/registry"
+	fakeregistry "github.com/gardener/gardener/pkg/nodeagent/registry/fake"
+)
+
+var _ = Describe("FakeExtractor", func() {
+	Describe("#CopyFromImage", func() {
+		var (
+			ctx = context.Background()
+
+			fakeFS          afero.Afero
+			extractor       registry.Extractor
+			sourceDirectory string
+			destinationFile string
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			fakeFS = afero.Afero{Fs: afero.NewMemMapFs()}
+
+			sourceDirectory, err = fakeFS.TempDir("", "copy-from-image-sourcedir-")
+			Expect(err).NotTo(HaveOccurred())
+			createFile(fakeFS, path.Join(sourceDirectory, "foobar"), "foobar content", 0755)
+
+			extractor = fakeregistry.NewExtractor(fakeFS, sourceDirectory)
+			destinationFile = "/destdir/foobar"
+		})
+
+		It("should copy the file to the destination and clean up the image mount directory", func() {
+			Expect(extractor.CopyFromImage(ctx, "test:latest", "foobar", destinationFile, 0750)).To(Succeed())
+			checkFile(fakeFS, destinationFile, "foobar content", 0750)
+			expectNoLeakedMountDirectory(fakeFS)
+		})
+
+		It("should not leak the image mount directory when writing the destination file fails", func() {
+			Expect(fakeFS.MkdirAll(destinationFile, 0755)).To(Succeed())
+
+			Expect(extractor.CopyFromImage(ctx, "test:latest", "foobar", destinationFile, 0750)).To(MatchError(ContainSubstring("exists but is not a regular file")))
+			expectNoLeakedMountDirectory(fakeFS)
+		})
+	})
+})
+
+func expectNoLeakedMountDirectory(fakeFS afero.Afero) {
+	entries, err := fakeFS.ReadDir(os.TempDir())
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	for _, entry := range entries {
+		ExpectWithOffset(1, strings.HasPrefix(entry.Name(), fakeregistry.MountDirectoryPrefix)).To(BeFalse(), "mount directory %q was not cleaned up", entry.Name())
+	}
+}
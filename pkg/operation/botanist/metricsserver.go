@@ -36,9 +36,10 @@ func (b *Botanist) DefaultMetricsServer() (component.DeployWaiter, error) {
 	}
 
 	values := metricsserver.Values{
-		Image:             image.String(),
-		VPAEnabled:        b.Shoot.WantsVerticalPodAutoscaler,
-		KubeAPIServerHost: kubeAPIServerHost,
+		Image:              image.String(),
+		VPAEnabled:         b.Shoot.WantsVerticalPodAutoscaler,
+		KubeAPIServerHost:  kubeAPIServerHost,
+		RegisterAPIService: true,
 	}
 
 	return metricsserver.New(
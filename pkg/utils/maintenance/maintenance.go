@@ -0,0 +1,224 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package maintenance contains common logic for determining the target version of a maintenance operation.
+package maintenance
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+)
+
+// Strategy describes how the next higher version is determined during maintenance.
+type Strategy string
+
+const (
+	// StrategyPatch auto-updates to the latest patch version of the current minor version and forcefully updates
+	// expired versions to the next higher minor version.
+	StrategyPatch Strategy = "patch"
+	// StrategyMinor auto-updates to the latest patch and minor version of the current major version and forcefully
+	// updates expired versions to the next higher major version.
+	StrategyMinor Strategy = "minor"
+	// StrategyMajor auto-updates to the overall latest version. Expired versions cannot be forcefully updated because
+	// there is no higher version according to the strategy.
+	StrategyMajor Strategy = "major"
+	// StrategyKubernetes auto-updates to the latest patch version of the current minor version and forcefully updates
+	// expired versions to the consecutive minor version (required for Kubernetes version upgrades).
+	StrategyKubernetes Strategy = "kubernetes"
+)
+
+// Reason describes why a certain target version was (or was not) determined.
+type Reason string
+
+const (
+	// ReasonUpToDate means the current version is already up-to-date according to the policy, no update is necessary.
+	ReasonUpToDate Reason = "UpToDate"
+	// ReasonAutoUpdate means a higher qualifying version was found for an automatic update.
+	ReasonAutoUpdate Reason = "AutoUpdate"
+	// ReasonForceUpdate means the current version is expired and a version for a forceful update was found.
+	ReasonForceUpdate Reason = "ForceUpdate"
+)
+
+// Policy describes the rules for determining the target version of a maintenance operation.
+type Policy struct {
+	// Strategy describes how the next higher version is determined.
+	Strategy Strategy
+	// AutoUpdateEnabled specifies whether automatic updates to higher qualifying versions are performed. Expired
+	// versions are forcefully updated independent of this setting.
+	AutoUpdateEnabled bool
+	// CurrentVersionExpired specifies whether the current version is expired and hence must be updated forcefully in
+	// case no qualifying version for an automatic update is available.
+	CurrentVersionExpired bool
+	// MinimalJump specifies that a forceful update selects the lowest qualifying non-expired version within the bounds
+	// of the strategy instead of the highest one, keeping the jump (and hence the potential disruption) as small as
+	// possible.
+	MinimalJump bool
+}
+
+// FindVersionForPolicy determines the version the given <current> version must be updated to during maintenance,
+// considering the given <candidates> and the rules of the given <policy>. It returns nil and ReasonUpToDate when no
+// update is necessary. It returns an error when the current version is expired but no version for a forceful update
+// can be determined.
+func FindVersionForPolicy(current string, candidates []gardencorev1beta1.ExpirableVersion, policy Policy) (*string, Reason, error) {
+	var (
+		getHigherVersionAutoUpdate  func(versions []gardencorev1beta1.ExpirableVersion, currentVersion string) (bool, string, error)
+		getHigherVersionForceUpdate func(versions []gardencorev1beta1.ExpirableVersion, currentVersion string) (bool, string, error)
+	)
+
+	switch policy.Strategy {
+	case StrategyPatch:
+		getHigherVersionAutoUpdate = v1beta1helper.GetLatestVersionForPatchAutoUpdate
+		getHigherVersionForceUpdate = v1beta1helper.GetVersionForForcefulUpdateToNextHigherMinor
+	case StrategyMinor:
+		getHigherVersionAutoUpdate = v1beta1helper.GetLatestVersionForMinorAutoUpdate
+		getHigherVersionForceUpdate = v1beta1helper.GetVersionForForcefulUpdateToNextHigherMajor
+	case StrategyMajor:
+		getHigherVersionAutoUpdate = v1beta1helper.GetOverallLatestVersionForAutoUpdate
+		// there is no higher version than the overall latest version, so an expired version cannot be updated forcefully
+		getHigherVersionForceUpdate = func(_ []gardencorev1beta1.ExpirableVersion, currentVersion string) (bool, string, error) {
+			return false, "", fmt.Errorf("no version higher than the overall latest version %q exists", currentVersion)
+		}
+	case StrategyKubernetes:
+		getHigherVersionAutoUpdate = v1beta1helper.GetLatestVersionForPatchAutoUpdate
+		getHigherVersionForceUpdate = v1beta1helper.GetVersionForForcefulUpdateToConsecutiveMinor
+	default:
+		return nil, "", fmt.Errorf("unknown update strategy %q", policy.Strategy)
+	}
+
+	// sort and de-duplicate the candidates so that the selection is deterministic regardless of the ordering in the
+	// CloudProfile
+	candidates, err := versionutils.SortExpirableVersions(candidates)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !policy.AutoUpdateEnabled && !policy.CurrentVersionExpired {
+		return nil, ReasonUpToDate, nil
+	}
+
+	higherQualifyingVersionFound, versionForAutoUpdate, err := getHigherVersionAutoUpdate(candidates, current)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to determine a higher version for automatic update: %w", err)
+	}
+	if higherQualifyingVersionFound {
+		return &versionForAutoUpdate, ReasonAutoUpdate, nil
+	}
+
+	// the current version is already the latest version according to the strategy
+	if !policy.CurrentVersionExpired {
+		return nil, ReasonUpToDate, nil
+	}
+
+	forceUpdateVersionAvailable, versionForForceUpdate, err := getHigherVersionForceUpdate(candidates, current)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to determine version for forceful update: %w", err)
+	}
+	if !forceUpdateVersionAvailable {
+		return nil, "", fmt.Errorf("cannot perform forceful update of expired version %q. No suitable version found in CloudProfile - this is most likely a misconfiguration of the CloudProfile", current)
+	}
+
+	if policy.MinimalJump {
+		versionForForceUpdate, err = lowestVersionForForceUpdate(candidates, current, versionForForceUpdate)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return &versionForForceUpdate, ReasonForceUpdate, nil
+}
+
+// DetermineKubernetesVersion determines the Kubernetes version the given <current> version must be updated to during
+// maintenance, considering the versions offered by the given <cloudProfile>. It returns a nil target and ReasonUpToDate
+// when no update is necessary. The returned boolean indicates whether the update crosses the Kubernetes 1.27 boundary
+// from which on the static token kubeconfig can no longer be enabled, i.e. whether the caller must disable it.
+func DetermineKubernetesVersion(cloudProfile *gardencorev1beta1.CloudProfile, current string, autoUpdate, expired bool) (*string, Reason, bool, error) {
+	target, reason, err := FindVersionForPolicy(current, cloudProfile.Spec.Kubernetes.Versions, Policy{
+		Strategy:              StrategyKubernetes,
+		AutoUpdateEnabled:     autoUpdate,
+		CurrentVersionExpired: expired,
+	})
+	if err != nil || target == nil {
+		return target, reason, false, err
+	}
+
+	disableStaticTokenKubeconfig, err := updateCrossesStaticTokenKubeconfigBoundary(current, *target)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	return target, reason, disableStaticTokenKubeconfig, nil
+}
+
+// updateCrossesStaticTokenKubeconfigBoundary returns whether an update from <current> to <target> crosses the
+// Kubernetes 1.27 boundary from which on the static token kubeconfig can no longer be enabled.
+func updateCrossesStaticTokenKubeconfigBoundary(current, target string) (bool, error) {
+	currentSemVer, err := semver.NewVersion(current)
+	if err != nil {
+		return false, err
+	}
+	targetSemVer, err := semver.NewVersion(target)
+	if err != nil {
+		return false, err
+	}
+
+	return versionutils.ConstraintK8sLess127.Check(currentSemVer) && versionutils.ConstraintK8sGreaterEqual127.Check(targetSemVer), nil
+}
+
+// lowestVersionForForceUpdate refines the given force-update <target> to the lowest non-expired, non-preview candidate
+// that is higher than <current> but not higher than <target>. The <target> already respects the bounds of the strategy,
+// so any candidate in between constitutes a smaller but still valid jump. If no such candidate exists (e.g. because the
+// target itself is an expired fallback), the target is returned unchanged.
+func lowestVersionForForceUpdate(candidates []gardencorev1beta1.ExpirableVersion, current, target string) (string, error) {
+	currentSemVer, err := semver.NewVersion(current)
+	if err != nil {
+		return "", err
+	}
+	targetSemVer, err := semver.NewVersion(target)
+	if err != nil {
+		return "", err
+	}
+
+	// the candidates are sorted in ascending order, so the first qualifying candidate is the lowest one
+	for _, candidate := range candidates {
+		if candidate.Classification != nil && *candidate.Classification == gardencorev1beta1.ClassificationPreview {
+			continue
+		}
+
+		candidateSemVer, err := semver.NewVersion(candidate.Version)
+		if err != nil {
+			return "", err
+		}
+
+		if !candidateSemVer.GreaterThan(currentSemVer) || candidateSemVer.GreaterThan(targetSemVer) {
+			continue
+		}
+
+		isExpired, err := v1beta1helper.FilterExpiredVersion()(candidate, candidateSemVer)
+		if err != nil {
+			return "", err
+		}
+		if isExpired {
+			continue
+		}
+
+		return candidate.Version, nil
+	}
+
+	return target, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/gardener/gardener/pkg/utils/maintenance"
+)
+
+var _ = Describe("Maintenance", func() {
+	Describe("#FindVersionForPolicy", func() {
+		var (
+			expirationDateInThePast = metav1.Time{Time: time.Now().AddDate(0, 0, -1)}
+			previewClassification   = gardencorev1beta1.ClassificationPreview
+
+			candidates []gardencorev1beta1.ExpirableVersion
+		)
+
+		BeforeEach(func() {
+			candidates = []gardencorev1beta1.ExpirableVersion{
+				{Version: "1.0.0"},
+				{Version: "1.0.2"},
+				{Version: "1.0.3", Classification: &previewClassification},
+				{Version: "1.1.1"},
+				{Version: "1.2.0", Classification: &previewClassification},
+				{Version: "2.0.1"},
+			}
+		})
+
+		DescribeTable("should determine the expected target version and reason",
+			func(current string, policy Policy, expectedTarget *string, expectedReason Reason) {
+				target, reason, err := FindVersionForPolicy(current, candidates, policy)
+
+				Expect(err).NotTo(HaveOccurred())
+				if expectedTarget == nil {
+					Expect(target).To(BeNil())
+				} else {
+					Expect(target).To(gstruct.PointTo(Equal(*expectedTarget)))
+				}
+				Expect(reason).To(Equal(expectedReason))
+			},
+
+			Entry("no update when auto-update is disabled and the version is not expired",
+				"1.0.0", Policy{Strategy: StrategyPatch}, nil, ReasonUpToDate),
+			Entry("no update when the latest patch version is already used",
+				"1.0.2", Policy{Strategy: StrategyPatch, AutoUpdateEnabled: true}, nil, ReasonUpToDate),
+			Entry("auto-update to the latest non-preview patch version",
+				"1.0.0", Policy{Strategy: StrategyPatch, AutoUpdateEnabled: true}, ptr("1.0.2"), ReasonAutoUpdate),
+			Entry("auto-update to the latest patch version even when the current version is expired",
+				"1.0.0", Policy{Strategy: StrategyPatch, CurrentVersionExpired: true}, ptr("1.0.2"), ReasonAutoUpdate),
+			Entry("auto-update to the latest version of the current major version",
+				"1.0.2", Policy{Strategy: StrategyMinor, AutoUpdateEnabled: true}, ptr("1.1.1"), ReasonAutoUpdate),
+			Entry("auto-update to the overall latest version",
+				"1.1.1", Policy{Strategy: StrategyMajor, AutoUpdateEnabled: true}, ptr("2.0.1"), ReasonAutoUpdate),
+			Entry("force-update an expired version to the next higher minor version",
+				"1.0.2", Policy{Strategy: StrategyPatch, CurrentVersionExpired: true}, ptr("1.1.1"), ReasonForceUpdate),
+			Entry("force-update an expired version to the consecutive minor version",
+				"1.0.2", Policy{Strategy: StrategyKubernetes, CurrentVersionExpired: true}, ptr("1.1.1"), ReasonForceUpdate),
+			Entry("force-update an expired version to the next higher major version",
+				"1.1.1", Policy{Strategy: StrategyMinor, CurrentVersionExpired: true}, ptr("2.0.1"), ReasonForceUpdate),
+		)
+
+		It("should prefer a force-update to an expired version of the next minor over skipping the minor", func() {
+			candidates = []gardencorev1beta1.ExpirableVersion{
+				{Version: "1.0.2"},
+				{Version: "1.1.1", ExpirationDate: &expirationDateInThePast},
+				{Version: "1.2.0"},
+			}
+
+			target, reason, err := FindVersionForPolicy("1.0.2", candidates, Policy{Strategy: StrategyKubernetes, CurrentVersionExpired: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(gstruct.PointTo(Equal("1.1.1")))
+			Expect(reason).To(Equal(ReasonForceUpdate))
+		})
+
+		It("should force-update to the lowest qualifying version within the bound when minimal jumps are requested", func() {
+			candidates = []gardencorev1beta1.ExpirableVersion{
+				{Version: "1.0.2"},
+				{Version: "1.1.0"},
+				{Version: "1.1.1"},
+			}
+
+			target, reason, err := FindVersionForPolicy("1.0.2", candidates, Policy{Strategy: StrategyPatch, CurrentVersionExpired: true, MinimalJump: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(gstruct.PointTo(Equal("1.1.0")))
+			Expect(reason).To(Equal(ReasonForceUpdate))
+		})
+
+		It("should skip expired and preview versions when determining the minimal jump", func() {
+			candidates = []gardencorev1beta1.ExpirableVersion{
+				{Version: "1.1.1"},
+				{Version: "2.0.0", ExpirationDate: &expirationDateInThePast},
+				{Version: "2.0.1", Classification: &previewClassification},
+				{Version: "2.0.2"},
+				{Version: "2.0.3"},
+			}
+
+			target, reason, err := FindVersionForPolicy("1.1.1", candidates, Policy{Strategy: StrategyMinor, CurrentVersionExpired: true, MinimalJump: true})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(gstruct.PointTo(Equal("2.0.2")))
+			Expect(reason).To(Equal(ReasonForceUpdate))
+		})
+
+		It("should fail when an expired version cannot be force-updated", func() {
+			candidates = []gardencorev1beta1.ExpirableVersion{
+				{Version: "1.0.2", ExpirationDate: &expirationDateInThePast},
+			}
+
+			target, _, err := FindVersionForPolicy("1.0.2", candidates, Policy{Strategy: StrategyKubernetes, CurrentVersionExpired: true})
+
+			Expect(err).To(MatchError(ContainSubstring("cannot perform forceful update of expired version")))
+			Expect(target).To(BeNil())
+		})
+
+		It("should fail when the overall latest version is expired with strategy major", func() {
+			_, _, err := FindVersionForPolicy("2.0.1", candidates, Policy{Strategy: StrategyMajor, CurrentVersionExpired: true})
+
+			Expect(err).To(MatchError(ContainSubstring("no version higher than the overall latest version")))
+		})
+
+		It("should fail for an unknown strategy", func() {
+			_, _, err := FindVersionForPolicy("1.0.0", candidates, Policy{Strategy: "foo", AutoUpdateEnabled: true})
+
+			Expect(err).To(MatchError(ContainSubstring("unknown update strategy")))
+		})
+	})
+
+	Describe("#DetermineKubernetesVersion", func() {
+		var cloudProfile *gardencorev1beta1.CloudProfile
+
+		BeforeEach(func() {
+			cloudProfile = &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					Kubernetes: gardencorev1beta1.KubernetesSettings{
+						Versions: []gardencorev1beta1.ExpirableVersion{
+							{Version: "1.26.0"},
+							{Version: "1.26.4"},
+							{Version: "1.27.1"},
+						},
+					},
+				},
+			}
+		})
+
+		DescribeTable("should determine the expected target version, reason, and static token kubeconfig flag",
+			func(current string, autoUpdate, expired bool, expectedTarget *string, expectedReason Reason, expectedDisableStaticTokenKubeconfig bool) {
+				target, reason, disableStaticTokenKubeconfig, err := DetermineKubernetesVersion(cloudProfile, current, autoUpdate, expired)
+
+				Expect(err).NotTo(HaveOccurred())
+				if expectedTarget == nil {
+					Expect(target).To(BeNil())
+				} else {
+					Expect(target).To(gstruct.PointTo(Equal(*expectedTarget)))
+				}
+				Expect(reason).To(Equal(expectedReason))
+				Expect(disableStaticTokenKubeconfig).To(Equal(expectedDisableStaticTokenKubeconfig))
+			},
+
+			Entry("no update when auto-update is disabled and the version is not expired",
+				"1.26.0", false, false, nil, ReasonUpToDate, false),
+			Entry("no update when the latest patch version is already used",
+				"1.26.4", true, false, nil, ReasonUpToDate, false),
+			Entry("auto-update to the latest patch version of the current minor version",
+				"1.26.0", true, false, ptr("1.26.4"), ReasonAutoUpdate, false),
+			Entry("force-update an expired version to the latest patch version of the current minor version",
+				"1.26.0", false, true, ptr("1.26.4"), ReasonAutoUpdate, false),
+			Entry("force-update an expired version to the consecutive minor version and disable the static token kubeconfig",
+				"1.26.4", false, true, ptr("1.27.1"), ReasonForceUpdate, true),
+			Entry("do not disable the static token kubeconfig when the update stays within Kubernetes >= 1.27",
+				"1.27.0", true, false, ptr("1.27.1"), ReasonAutoUpdate, false),
+		)
+
+		It("should fail when an expired version cannot be force-updated", func() {
+			target, _, _, err := DetermineKubernetesVersion(cloudProfile, "1.27.1", false, true)
+
+			Expect(err).To(MatchError(ContainSubstring("cannot perform forceful update of expired version")))
+			Expect(target).To(BeNil())
+		})
+	})
+})
+
+func ptr(s string) *string { return &s }
@@ -151,6 +151,22 @@ func ParseMaintenanceTimeWindow(begin, end string) (*MaintenanceTimeWindow, erro
 	return NewMaintenanceTimeWindow(maintenanceWindowBegin, maintenanceWindowEnd), nil
 }
 
+// ValidateMaintenanceTimeWindow checks that the given begin and end of a time window parse correctly in the
+// maintenance format and that the resulting window lasts at least <minDuration>. It returns a descriptive error
+// otherwise.
+func ValidateMaintenanceTimeWindow(begin, end string, minDuration time.Duration) error {
+	window, err := ParseMaintenanceTimeWindow(begin, end)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance time window (begin=%s, end=%s): %w", begin, end, err)
+	}
+
+	if duration := window.Duration(); duration < minDuration {
+		return fmt.Errorf("maintenance time window (begin=%s, end=%s) only lasts %s but must last at least %s", begin, end, duration, minDuration)
+	}
+
+	return nil
+}
+
 // String returns the string representation of the time window.
 func (m *MaintenanceTimeWindow) String() string {
 	return fmt.Sprintf("begin=%s, end=%s", m.begin, m.end)
@@ -181,12 +197,14 @@ func (m *MaintenanceTimeWindow) WithEnd(end *MaintenanceTime) *MaintenanceTimeWi
 	return NewMaintenanceTimeWindow(m.begin, end)
 }
 
-// Contains returns true in case the given time is within the time window.
+// Contains returns true in case the given time is within the time window. Both the begin and the end of the window
+// are inclusive. Time windows crossing midnight (begin > end, e.g. 23:00-02:00) are handled correctly, i.e. they
+// contain all times from the begin until midnight and from midnight until the end on the following day.
 func (m *MaintenanceTimeWindow) Contains(tTime time.Time) bool {
 	t := timeToMaintenanceTime(tTime)
 
 	if m.spansDifferentDays() {
-		return !(t.Compare(m.end) > 0 && t.Compare(m.begin) < 0)
+		return t.Compare(m.begin) >= 0 || t.Compare(m.end) <= 0
 	}
 	return t.Compare(m.begin) >= 0 && t.Compare(m.end) <= 0
 }
@@ -228,6 +246,19 @@ func (m *MaintenanceTimeWindow) RandomDurationUntilNext(from time.Time, shiftBeg
 	return time.Duration(int64(begin.Sub(from)) + RandomFunc(0, delta.Nanoseconds()))
 }
 
+// DurationUntilNext computes the exact duration from <from> until the next start of the time window. If <from> is
+// equal to or after today's window start, the duration until the window start on the following day is returned.
+func (m *MaintenanceTimeWindow) DurationUntilNext(from time.Time) time.Duration {
+	from = from.UTC()
+
+	begin := m.adjustedBegin(from)
+	if !begin.After(from) {
+		begin = begin.AddDate(0, 0, 1)
+	}
+
+	return begin.Sub(from)
+}
+
 // Duration returns the duration of the maintenance time window.
 func (m *MaintenanceTimeWindow) Duration() time.Duration {
 	var (
@@ -233,6 +233,16 @@ var _ = Describe("utils", func() {
 			Entry("begin and end on different day (23-1)", from23to1, newTime(1, 0, 0, 0), true),
 			Entry("begin and end on different day (23-1)", from23to1, newTime(0, 59, 0, 0), true),
 
+			Entry("begin and end on different day (23-1), exact begin boundary", from23to1, newTime(23, 0, 0, 0), true),
+			Entry("begin and end on different day (23-1), exact end boundary", from23to1, newTime(1, 0, 0, 0), true),
+			Entry("begin and end on different day (23-1), one minute before begin", from23to1, newTime(22, 59, 0, 0), false),
+			Entry("begin and end on different day (23-1), one minute after end", from23to1, newTime(1, 1, 0, 0), false),
+			Entry("begin and end on different day (23-1), midnight itself", from23to1, newTime(0, 0, 0, 0), true),
+
+			Entry("begin and end on different day (23-0), exact begin boundary", from23to0, newTime(23, 0, 0, 0), true),
+			Entry("begin and end on different day (23-0), exact end boundary", from23to0, newTime(0, 0, 0, 0), true),
+			Entry("begin and end on different day (23-0), one minute after end", from23to0, newTime(0, 1, 0, 0), false),
+
 			Entry("begin and end on different day (23-0)", from23to0, newTime(22, 59, 59, 9999), false),
 			Entry("begin and end on different day (23-0)", from23to0, newTime(1, 0, 0, 0), false),
 			Entry("begin and end on different day (23-0)", from23to0, newTime(23, 0, 0, 0), true),
@@ -284,6 +294,20 @@ var _ = Describe("utils", func() {
 			Entry("(23-0), shift begin if contained, does not contain now (after)", from23to0, true, newTime(0, 59, 1, 0), 23*time.Hour+59*time.Second),
 		)
 
+		DescribeTable("#DurationUntilNext",
+			func(maintenanceTimeWindow *MaintenanceTimeWindow, from time.Time, expected time.Duration) {
+				Expect(maintenanceTimeWindow.DurationUntilNext(from)).To(Equal(expected))
+			},
+
+			Entry("(16-19), before today's begin", from16to19, newTime(15, 0, 0, 0), time.Hour),
+			Entry("(16-19), exactly at begin", from16to19, newTime(16, 0, 0, 0), 24*time.Hour),
+			Entry("(16-19), within the window", from16to19, newTime(17, 30, 0, 0), 22*time.Hour+30*time.Minute),
+			Entry("(16-19), after the window", from16to19, newTime(20, 0, 0, 0), 20*time.Hour),
+			Entry("(23-1), before begin", from23to1, newTime(22, 0, 0, 0), time.Hour),
+			Entry("(23-1), within the window after midnight", from23to1, newTime(0, 30, 0, 0), 22*time.Hour+30*time.Minute),
+			Entry("(0-1), exactly at midnight begin", from0to1, newTime(0, 0, 0, 0), 24*time.Hour),
+		)
+
 		DescribeTable("#Duration",
 			func(maintenanceTimeWindow *MaintenanceTimeWindow, expected time.Duration) {
 				Expect(maintenanceTimeWindow.Duration()).To(Equal(expected))
@@ -295,6 +319,28 @@ var _ = Describe("utils", func() {
 			Entry("begin and end on different day (23-0)", from23to0, 1*time.Hour),
 		)
 	})
+
+	Describe("#ValidateMaintenanceTimeWindow", func() {
+		It("should accept a window lasting at least the minimum duration", func() {
+			Expect(ValidateMaintenanceTimeWindow("160000+0000", "190000+0000", time.Hour)).To(Succeed())
+		})
+
+		It("should accept a window crossing midnight", func() {
+			Expect(ValidateMaintenanceTimeWindow("230000+0000", "010000+0000", time.Hour)).To(Succeed())
+		})
+
+		It("should reject a malformed begin time", func() {
+			Expect(ValidateMaintenanceTimeWindow("foo", "190000+0000", time.Hour)).To(MatchError(ContainSubstring("could not parse begin time")))
+		})
+
+		It("should reject a malformed end time", func() {
+			Expect(ValidateMaintenanceTimeWindow("160000+0000", "25", time.Hour)).To(MatchError(ContainSubstring("could not parse end time")))
+		})
+
+		It("should reject a window shorter than the minimum duration", func() {
+			Expect(ValidateMaintenanceTimeWindow("160000+0000", "160500+0000", 30*time.Minute)).To(MatchError(ContainSubstring("must last at least 30m0s")))
+		})
+	})
 })
 
 func newTime(hour, minute, second, nanosecond int) time.Time {
@@ -16,10 +16,15 @@ package version
 
 import (
 	"fmt"
+	"math"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 )
 
 var (
@@ -74,6 +79,12 @@ func CompareVersions(version1, operator, version2 string) (bool, error) {
 	return CheckVersionMeetsConstraint(v1, fmt.Sprintf("%s %s", operator, v2))
 }
 
+// EqualIgnoringBuildMetadata returns true if <version1> and <version2> are semantically equal.
+// Build metadata (everything after a `+`) is ignored for the comparison, as mandated by semver.
+func EqualIgnoringBuildMetadata(version1, version2 string) (bool, error) {
+	return CompareVersions(version1, "=", version2)
+}
+
 // CheckVersionMeetsConstraint returns true if the <version> meets the <constraint>.
 func CheckVersionMeetsConstraint(version, constraint string) (bool, error) {
 	c, err := semver.NewConstraint(constraint)
@@ -89,6 +100,114 @@ func CheckVersionMeetsConstraint(version, constraint string) (bool, error) {
 	return c.Check(v), nil
 }
 
+// ToMinor returns the patch-zero version of the minor of <v>, e.g. ToMinor(1.25.3) returns 1.25.0.
+// Pre-release and build metadata are stripped.
+func ToMinor(v *semver.Version) *semver.Version {
+	return semver.New(v.Major(), v.Minor(), 0, "", "")
+}
+
+// MinorSkew returns the absolute difference between the minor versions of <a> and <b>.
+// Versions with different major versions are considered arbitrarily far apart, i.e. a skew of math.MaxInt is returned.
+func MinorSkew(a, b *semver.Version) int {
+	if a.Major() != b.Major() {
+		return math.MaxInt
+	}
+
+	if a.Minor() > b.Minor() {
+		return int(a.Minor() - b.Minor())
+	}
+	return int(b.Minor() - a.Minor())
+}
+
+// WithinMinorSkew returns true if the minor versions of <a> and <b> differ by at most <max>.
+// Versions with different major versions are never within any skew.
+func WithinMinorSkew(a, b *semver.Version, max int) bool {
+	return MinorSkew(a, b) <= max
+}
+
+// IsSupportedUpgrade returns true if upgrading from <from> to <to> is a supported Kubernetes upgrade path,
+// i.e. <to> is not lower than <from> and raises the minor version by at most one. Changing the major version
+// or skipping a minor version is not supported.
+func IsSupportedUpgrade(from, to *semver.Version) bool {
+	if from.Major() != to.Major() || to.LessThan(from) {
+		return false
+	}
+
+	return to.Minor()-from.Minor() <= 1
+}
+
+// MinorsBetween returns the ordered minor versions strictly between the minors of <from> and <to>
+// in the format `<major>.<minor>`, e.g. MinorsBetween(1.24.x, 1.27.x) returns ["1.25", "1.26"].
+// It returns nil if the versions have different majors or if there is no intermediate minor.
+func MinorsBetween(from, to *semver.Version) []string {
+	if from.Major() != to.Major() || to.Minor() <= from.Minor()+1 {
+		return nil
+	}
+
+	var minors []string
+	for minor := from.Minor() + 1; minor < to.Minor(); minor++ {
+		minors = append(minors, fmt.Sprintf("%d.%d", from.Major(), minor))
+	}
+	return minors
+}
+
+// SortExpirableVersions returns a copy of the given expirable versions which is sorted ascending by semantic version
+// and de-duplicated by version string (keeping the first occurrence). This makes version selection deterministic for
+// CloudProfiles with unsorted or duplicated version lists. An error is returned when a version cannot be parsed.
+func SortExpirableVersions(versions []gardencorev1beta1.ExpirableVersion) ([]gardencorev1beta1.ExpirableVersion, error) {
+	var (
+		out  = make([]gardencorev1beta1.ExpirableVersion, 0, len(versions))
+		seen = map[string]struct{}{}
+	)
+
+	for _, version := range versions {
+		if _, err := semver.NewVersion(version.Version); err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", version.Version, err)
+		}
+
+		if _, ok := seen[version.Version]; ok {
+			continue
+		}
+		seen[version.Version] = struct{}{}
+
+		out = append(out, version)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return semver.MustParse(out[i].Version).LessThan(semver.MustParse(out[j].Version))
+	})
+
+	return out, nil
+}
+
+// IsLatestPatchInMinor returns whether <v> is the highest patch version of its minor version among <all>.
+// Versions not supporting the given <architecture> are not considered; an empty <architecture> matches every
+// version. <v> itself does not need to be contained in <all>. An error is returned when a version cannot be
+// parsed.
+func IsLatestPatchInMinor(v string, all []gardencorev1beta1.MachineImageVersion, architecture string) (bool, error) {
+	version, err := semver.NewVersion(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q: %w", v, err)
+	}
+
+	for _, other := range all {
+		if architecture != "" && len(other.Architectures) > 0 && !slices.Contains(other.Architectures, architecture) {
+			continue
+		}
+
+		otherVersion, err := semver.NewVersion(other.Version)
+		if err != nil {
+			return false, fmt.Errorf("invalid version %q: %w", other.Version, err)
+		}
+
+		if otherVersion.Major() == version.Major() && otherVersion.Minor() == version.Minor() && otherVersion.GreaterThan(version) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func normalize(version string) string {
 	v := strings.Replace(version, "v", "", -1)
 	idx := strings.IndexAny(v, "-+")
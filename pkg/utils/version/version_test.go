@@ -15,11 +15,14 @@
 package version_test
 
 import (
+	"math"
+
 	"github.com/Masterminds/semver/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	. "github.com/gardener/gardener/pkg/utils/version"
 )
 
@@ -81,6 +84,85 @@ var _ = Describe("Version", func() {
 		Entry("no match", "1.2.3", ">", "1.2.4", BeFalse()),
 		Entry("match w/ suffix", "1.2.3-foo.12", ">", "v1.2.2-foo.23", BeTrue()),
 		Entry("no match w/ suffix", "1.2.3-foo.12", ">", "v1.2.4-foo.34", BeFalse()),
+		Entry("match w/ build metadata", "1.2.3+build.123", ">", "1.2.2", BeTrue()),
+		Entry("no match w/ build metadata", "1.2.3+build.123", ">", "1.2.4+build.456", BeFalse()),
+	)
+
+	DescribeTable("#EqualIgnoringBuildMetadata",
+		func(version1, version2 string, expected gomegatypes.GomegaMatcher) {
+			result, err := EqualIgnoringBuildMetadata(version1, version2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(expected)
+		},
+
+		Entry("equal w/o build metadata", "1.2.3", "1.2.3", BeTrue()),
+		Entry("equal w/ different build metadata", "1.2.3+a", "1.2.3+b", BeTrue()),
+		Entry("equal w/ build metadata on one side", "1.2.3+build.123", "v1.2.3", BeTrue()),
+		Entry("not equal", "1.2.3+a", "1.2.4+a", BeFalse()),
+	)
+
+	DescribeTable("#ToMinor",
+		func(version, expected string) {
+			Expect(ToMinor(semver.MustParse(version)).String()).To(Equal(expected))
+		},
+
+		Entry("patch-zero version", "1.25.0", "1.25.0"),
+		Entry("version with patch", "1.25.4", "1.25.0"),
+		Entry("version with pre-release suffix", "1.26.3-alpha.1", "1.26.0"),
+		Entry("version with build metadata", "1.26.3+build.123", "1.26.0"),
+		Entry("version with pre-release suffix and build metadata", "2.0.1-rc.1+build.123", "2.0.0"),
+	)
+
+	DescribeTable("#MinorSkew",
+		func(a, b string, expected int) {
+			Expect(MinorSkew(semver.MustParse(a), semver.MustParse(b))).To(Equal(expected))
+		},
+
+		Entry("equal versions", "1.25.0", "1.25.0", 0),
+		Entry("same minor, different patch", "1.25.0", "1.25.4", 0),
+		Entry("one minor apart", "1.25.0", "1.26.1", 1),
+		Entry("one minor apart, reversed", "1.26.1", "1.25.0", 1),
+		Entry("three minors apart", "1.24.0", "1.27.0", 3),
+		Entry("different majors", "1.25.0", "2.25.0", math.MaxInt),
+	)
+
+	DescribeTable("#WithinMinorSkew",
+		func(a, b string, max int, matcher gomegatypes.GomegaMatcher) {
+			Expect(WithinMinorSkew(semver.MustParse(a), semver.MustParse(b), max)).To(matcher)
+		},
+
+		Entry("equal versions", "1.25.0", "1.25.0", 0, BeTrue()),
+		Entry("one minor apart, max 1", "1.25.0", "1.26.0", 1, BeTrue()),
+		Entry("two minors apart, max 1", "1.25.0", "1.27.0", 1, BeFalse()),
+		Entry("three minors apart, max 3", "1.24.3", "1.27.0", 3, BeTrue()),
+		Entry("different majors, large max", "1.25.0", "2.25.0", 100, BeFalse()),
+	)
+
+	DescribeTable("#IsSupportedUpgrade",
+		func(from, to string, matcher gomegatypes.GomegaMatcher) {
+			Expect(IsSupportedUpgrade(semver.MustParse(from), semver.MustParse(to))).To(matcher)
+		},
+
+		Entry("same version", "1.25.0", "1.25.0", BeTrue()),
+		Entry("patch bump within minor", "1.25.0", "1.25.4", BeTrue()),
+		Entry("next minor", "1.25.4", "1.26.0", BeTrue()),
+		Entry("skipped minor", "1.25.0", "1.27.0", BeFalse()),
+		Entry("minor downgrade", "1.26.0", "1.25.4", BeFalse()),
+		Entry("patch downgrade", "1.25.4", "1.25.0", BeFalse()),
+		Entry("major upgrade", "1.27.0", "2.0.0", BeFalse()),
+	)
+
+	DescribeTable("#MinorsBetween",
+		func(from, to string, expected []string) {
+			Expect(MinorsBetween(semver.MustParse(from), semver.MustParse(to))).To(Equal(expected))
+		},
+
+		Entry("same minor", "1.25.0", "1.25.4", nil),
+		Entry("adjacent minors", "1.25.0", "1.26.2", nil),
+		Entry("one intermediate minor", "1.25.0", "1.27.0", []string{"1.26"}),
+		Entry("multiple intermediate minors", "1.24.3", "1.28.0", []string{"1.25", "1.26", "1.27"}),
+		Entry("downgrade", "1.27.0", "1.25.0", nil),
+		Entry("different majors", "1.25.0", "2.27.0", nil),
 	)
 
 	DescribeTable("#CheckVersionMeetsConstraint",
@@ -95,4 +177,106 @@ var _ = Describe("Version", func() {
 		Entry("match w/ suffix", "1.2.3-foo.12", "> v1.2.2-foo.23", BeTrue()),
 		Entry("no match w/ suffix", "1.2.3-foo.12", "> v1.2.4-foo.34", BeFalse()),
 	)
+
+	Describe("#SortExpirableVersions", func() {
+		It("should sort shuffled version lists ascending", func() {
+			sorted, err := SortExpirableVersions([]gardencorev1beta1.ExpirableVersion{
+				{Version: "1.26.3"},
+				{Version: "1.24.8"},
+				{Version: "1.27.0"},
+				{Version: "1.25.1"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sorted).To(Equal([]gardencorev1beta1.ExpirableVersion{
+				{Version: "1.24.8"},
+				{Version: "1.25.1"},
+				{Version: "1.26.3"},
+				{Version: "1.27.0"},
+			}))
+		})
+
+		It("should de-duplicate versions keeping the first occurrence", func() {
+			classificationPreview := gardencorev1beta1.ClassificationPreview
+
+			sorted, err := SortExpirableVersions([]gardencorev1beta1.ExpirableVersion{
+				{Version: "1.25.1", Classification: &classificationPreview},
+				{Version: "1.24.8"},
+				{Version: "1.25.1"},
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sorted).To(Equal([]gardencorev1beta1.ExpirableVersion{
+				{Version: "1.24.8"},
+				{Version: "1.25.1", Classification: &classificationPreview},
+			}))
+		})
+
+		It("should produce stable results regardless of the input ordering", func() {
+			sortedA, err := SortExpirableVersions([]gardencorev1beta1.ExpirableVersion{{Version: "1.25.1"}, {Version: "1.24.8"}, {Version: "1.25.1"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			sortedB, err := SortExpirableVersions([]gardencorev1beta1.ExpirableVersion{{Version: "1.24.8"}, {Version: "1.25.1"}, {Version: "1.25.1"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sortedA).To(Equal(sortedB))
+		})
+
+		It("should fail for versions which cannot be parsed", func() {
+			_, err := SortExpirableVersions([]gardencorev1beta1.ExpirableVersion{{Version: "not-a-version"}})
+			Expect(err).To(MatchError(ContainSubstring(`invalid version "not-a-version"`)))
+		})
+	})
+
+	Describe("#IsLatestPatchInMinor", func() {
+		machineImageVersion := func(version string, architectures ...string) gardencorev1beta1.MachineImageVersion {
+			return gardencorev1beta1.MachineImageVersion{
+				ExpirableVersion: gardencorev1beta1.ExpirableVersion{Version: version},
+				Architectures:    architectures,
+			}
+		}
+
+		DescribeTable("common cases",
+			func(version string, all []gardencorev1beta1.MachineImageVersion, architecture string, expected gomegatypes.GomegaMatcher) {
+				result, err := IsLatestPatchInMinor(version, all, architecture)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(expected)
+			},
+
+			Entry("latest patch in minor", "1.1.2", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.1"), machineImageVersion("1.1.2"), machineImageVersion("1.2.0")}, "", BeTrue()),
+			Entry("higher patch in minor exists", "1.1.1", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.2"), machineImageVersion("1.2.0")}, "", BeFalse()),
+			Entry("tie with itself", "1.1.2", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.2"), machineImageVersion("1.1.2")}, "", BeTrue()),
+			Entry("not contained but still latest", "1.1.3", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.2")}, "", BeTrue()),
+			Entry("other minors are irrelevant", "1.1.2", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.2.5"), machineImageVersion("2.1.9")}, "", BeTrue()),
+		)
+
+		It("should report a higher patch in the same minor", func() {
+			result, err := IsLatestPatchInMinor("1.1.1", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.2")}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("should ignore higher patches which do not support the architecture", func() {
+			all := []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.2", "arm64")}
+
+			result, err := IsLatestPatchInMinor("1.1.1", all, "amd64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeTrue())
+
+			result, err = IsLatestPatchInMinor("1.1.1", all, "arm64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("should consider versions without architecture information for every architecture", func() {
+			result, err := IsLatestPatchInMinor("1.1.1", []gardencorev1beta1.MachineImageVersion{machineImageVersion("1.1.2")}, "amd64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeFalse())
+		})
+
+		It("should fail for versions which cannot be parsed", func() {
+			_, err := IsLatestPatchInMinor("not-a-version", nil, "")
+			Expect(err).To(MatchError(ContainSubstring(`invalid version "not-a-version"`)))
+		})
+	})
 })
@@ -23,6 +23,7 @@ import (
 	"github.com/Masterminds/semver/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,9 +36,11 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
 	"github.com/gardener/gardener/pkg/nodeagent/apis/config"
 	"github.com/gardener/gardener/pkg/nodeagent/controller/operatingsystemconfig"
 	fakedbus "github.com/gardener/gardener/pkg/nodeagent/dbus/fake"
+	nodeagentmetrics "github.com/gardener/gardener/pkg/nodeagent/metrics"
 	fakeregistry "github.com/gardener/gardener/pkg/nodeagent/registry/fake"
 	"github.com/gardener/gardener/pkg/utils"
 )
@@ -61,6 +64,8 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 
 		imageMountDirectory string
 		cancelFunc          cancelFuncEnsurer
+
+		initialFilesWritten, initialUnitsRestarted float64
 	)
 
 	BeforeEach(func() {
@@ -248,6 +253,9 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 			},
 			Data: map[string][]byte{"osc.yaml": oscRaw},
 		}
+
+		initialFilesWritten = testutil.ToFloat64(nodeagentmetrics.FilesWritten)
+		initialUnitsRestarted = testutil.ToFloat64(nodeagentmetrics.UnitsRestarted)
 	})
 
 	BeforeEach(func() {
@@ -308,6 +316,10 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{unit7.Name}},
 		))
 
+		By("Assert that the reconciliation metrics have been incremented")
+		Expect(testutil.ToFloat64(nodeagentmetrics.FilesWritten)).To(BeNumerically(">", initialFilesWritten))
+		Expect(testutil.ToFloat64(nodeagentmetrics.UnitsRestarted)).To(BeNumerically(">", initialUnitsRestarted))
+
 		By("Expect that cancel func has not been called")
 		Expect(cancelFunc.called).To(BeFalse())
 	})
@@ -333,7 +345,7 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 		// delete file2
 		// add drop-in to unit2 and enable+start it
 		// disable unit4 and remove all drop-ins
-		// remove only first drop-in from unit5
+		// remove only first drop-in from unit5 and mark it for reload instead of restart
 		// move file3 from unit.files to files while keeping it unchanged
 		// the content of file5 (belonging to unit7) is changed, so unit7 is restarting
 		// file1, unit3, and gardener-node-agent unit are unchanged, so unit3 is not restarting and cancel func is not called
@@ -343,6 +355,7 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 		unit4.Enable = pointer.Bool(false)
 		unit4.DropIns = nil
 		unit5.DropIns = unit5.DropIns[1:]
+		unit5.ReloadInsteadOfRestart = pointer.Bool(true)
 		unit6.Files = nil
 		unit7.Files[0].Content.Inline.Data = "changeme"
 
@@ -398,7 +411,7 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 			fakedbus.SystemdAction{Action: fakedbus.ActionStop, UnitNames: []string{unit1.Name}},
 			fakedbus.SystemdAction{Action: fakedbus.ActionDaemonReload},
 			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{unit2.Name}},
-			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{unit5.Name}},
+			fakedbus.SystemdAction{Action: fakedbus.ActionReload, UnitNames: []string{unit5.Name}},
 			fakedbus.SystemdAction{Action: fakedbus.ActionStop, UnitNames: []string{unit4.Name}},
 			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{unit6.Name}},
 			fakedbus.SystemdAction{Action: fakedbus.ActionRestart, UnitNames: []string{unit7.Name}},
@@ -408,6 +421,126 @@ var _ = Describe("OperatingSystemConfig controller tests", func() {
 		Expect(cancelFunc.called).To(BeFalse())
 	})
 
+	It("should write gzip-encoded inline file content decompressed to disk", func() {
+		By("Wait for node annotations to be updated")
+		Eventually(func(g Gomega) map[string]string {
+			updatedNode := &corev1.Node{}
+			g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(node), updatedNode)).To(Succeed())
+			return updatedNode.Annotations
+		}).Should(HaveKeyWithValue("checksum/cloud-config-data", utils.ComputeSHA256Hex(oscRaw)))
+
+		compressedData, err := extensionsv1alpha1helper.FileCodecForID(extensionsv1alpha1.GzipB64FileCodecID).Encode([]byte("gzipped file content"))
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Add gzip-encoded file to the operating system config")
+		operatingSystemConfig.Spec.Files = append(operatingSystemConfig.Spec.Files, extensionsv1alpha1.File{
+			Path:    "/gzipped/file",
+			Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Encoding: "b64+gzip", Data: string(compressedData)}},
+		})
+
+		oscRaw, err = runtime.Encode(codec, operatingSystemConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		patch := client.MergeFrom(oscSecret.DeepCopy())
+		oscSecret.Data["osc.yaml"] = oscRaw
+		Expect(testClient.Patch(ctx, oscSecret, patch)).To(Succeed())
+
+		By("Wait for node annotations to be updated")
+		Eventually(func(g Gomega) map[string]string {
+			updatedNode := &corev1.Node{}
+			g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(node), updatedNode)).To(Succeed())
+			return updatedNode.Annotations
+		}).Should(HaveKeyWithValue("checksum/cloud-config-data", utils.ComputeSHA256Hex(oscRaw)))
+
+		By("Assert that the file was written decompressed")
+		assertFileOnDisk(fakeFS, "/gzipped/file", "gzipped file content", 0600)
+	})
+
+	It("should append inline file content to existing files and undo the append on removal", func() {
+		By("Wait for node annotations to be updated")
+		Eventually(func(g Gomega) map[string]string {
+			updatedNode := &corev1.Node{}
+			g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(node), updatedNode)).To(Succeed())
+			return updatedNode.Annotations
+		}).Should(HaveKeyWithValue("checksum/cloud-config-data", utils.ComputeSHA256Hex(oscRaw)))
+
+		By("Create base file provided by the machine image")
+		Expect(fakeFS.WriteFile("/etc/sysctl.d/99-base.conf", []byte("base-setting=1"), 0644)).To(Succeed())
+
+		appendFile := extensionsv1alpha1.File{
+			Path:        "/etc/sysctl.d/99-base.conf",
+			Content:     extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Encoding: "", Data: "appended-setting=2", Append: true}},
+			Permissions: pointer.Int32(0644),
+		}
+
+		By("Add file with append mode to the operating system config")
+		operatingSystemConfig.Spec.Files = append(operatingSystemConfig.Spec.Files, appendFile)
+
+		var err error
+		oscRaw, err = runtime.Encode(codec, operatingSystemConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		patch := client.MergeFrom(oscSecret.DeepCopy())
+		oscSecret.Data["osc.yaml"] = oscRaw
+		Expect(testClient.Patch(ctx, oscSecret, patch)).To(Succeed())
+
+		By("Wait for node annotations to be updated")
+		Eventually(func(g Gomega) map[string]string {
+			updatedNode := &corev1.Node{}
+			g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(node), updatedNode)).To(Succeed())
+			return updatedNode.Annotations
+		}).Should(HaveKeyWithValue("checksum/cloud-config-data", utils.ComputeSHA256Hex(oscRaw)))
+
+		By("Assert that the content was appended to the base file")
+		assertFileOnDisk(fakeFS, appendFile.Path, "base-setting=1\nappended-setting=2", 0644)
+
+		By("Change the file's permissions to trigger a re-apply without duplicating the appended content")
+		operatingSystemConfig.Spec.Files[len(operatingSystemConfig.Spec.Files)-1].Permissions = pointer.Int32(0640)
+
+		oscRaw, err = runtime.Encode(codec, operatingSystemConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		patch = client.MergeFrom(oscSecret.DeepCopy())
+		oscSecret.Data["osc.yaml"] = oscRaw
+		Expect(testClient.Patch(ctx, oscSecret, patch)).To(Succeed())
+
+		By("Wait for node annotations to be updated")
+		Eventually(func(g Gomega) map[string]string {
+			updatedNode := &corev1.Node{}
+			g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(node), updatedNode)).To(Succeed())
+			return updatedNode.Annotations
+		}).Should(HaveKeyWithValue("checksum/cloud-config-data", utils.ComputeSHA256Hex(oscRaw)))
+
+		By("Assert that the appended content was not duplicated")
+		content, err := fakeFS.ReadFile(appendFile.Path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("base-setting=1\nappended-setting=2"))
+
+		By("Remove the file with append mode from the operating system config again")
+		operatingSystemConfig.Spec.Files = operatingSystemConfig.Spec.Files[:len(operatingSystemConfig.Spec.Files)-1]
+
+		oscRaw, err = runtime.Encode(codec, operatingSystemConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		patch = client.MergeFrom(oscSecret.DeepCopy())
+		oscSecret.Data["osc.yaml"] = oscRaw
+		Expect(testClient.Patch(ctx, oscSecret, patch)).To(Succeed())
+
+		By("Wait for node annotations to be updated")
+		Eventually(func(g Gomega) map[string]string {
+			updatedNode := &corev1.Node{}
+			g.Expect(testClient.Get(ctx, client.ObjectKeyFromObject(node), updatedNode)).To(Succeed())
+			return updatedNode.Annotations
+		}).Should(HaveKeyWithValue("checksum/cloud-config-data", utils.ComputeSHA256Hex(oscRaw)))
+
+		By("Assert that only the appended content was removed from the base file")
+		Eventually(func(g Gomega) string {
+			content, err := fakeFS.ReadFile(appendFile.Path)
+			g.Expect(err).NotTo(HaveOccurred())
+			return string(content)
+		}).Should(Equal("base-setting=1"))
+	})
+
 	It("should call the cancel function when gardener-node-agent must be restarted itself", func() {
 		var lastAppliedOSC []byte
 		By("Wait last-applied OSC file to be persisted")